@@ -0,0 +1,154 @@
+package crosswire
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	// Embed the time zone database so time zones validate the same on
+	// machines without zoneinfo, such as minimal CI containers.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// accessWindowDays are the days an access window can recur on, in the order
+// of the week.
+var accessWindowDays = []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+var (
+	// startTimePattern matches 24-hour times such as 09:00.
+	startTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	// endTimePattern also accepts 24:00, for windows lasting until midnight.
+	endTimePattern = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
+)
+
+// accessWindowConfig is an element of access_windows whose attributes are all
+// known, at Path.
+type accessWindowConfig struct {
+	AccessWindow
+
+	Path path.Path
+}
+
+// accessWindowConfigs returns the elements of access_windows whose attributes
+// are all known, skipping the rest.
+func accessWindowConfigs(list types.List) []accessWindowConfig {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var windows []accessWindowConfig
+	for i, element := range list.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		attributes := object.Attributes()
+		days, _ := attributes["days"].(types.Set)
+		startTime, _ := attributes["start_time"].(types.String)
+		endTime, _ := attributes["end_time"].(types.String)
+		timeZone, _ := attributes["time_zone"].(types.String)
+		if days.IsNull() || days.IsUnknown() || !isKnownString(startTime) || !isKnownString(endTime) || !isKnownString(timeZone) {
+			continue
+		}
+
+		window := accessWindowConfig{
+			AccessWindow: AccessWindow{
+				StartTime: startTime.ValueString(),
+				EndTime:   endTime.ValueString(),
+				TimeZone:  timeZone.ValueString(),
+			},
+			Path: path.Root("access_windows").AtListIndex(i),
+		}
+		known := true
+		for _, day := range days.Elements() {
+			value, ok := day.(types.String)
+			if !ok || !isKnownString(value) {
+				known = false
+				break
+			}
+			window.Days = append(window.Days, value.ValueString())
+		}
+		if known {
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+func isKnownString(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// clockMinutes returns the minutes since midnight of a HH:MM time, reporting
+// false when it is not one.
+func clockMinutes(clock string) (int, bool) {
+	if !endTimePattern.MatchString(clock) {
+		return 0, false
+	}
+	hours, _ := strconv.Atoi(clock[:2])
+	minutes, _ := strconv.Atoi(clock[3:])
+	return hours*60 + minutes, true
+}
+
+// accessWindowIntervals returns the [start, end) minutes of the week, counted
+// from Monday 00:00, that window covers. Windows ending at or before their
+// start run past midnight into the next day, so intervals may end after the
+// end of the week.
+func accessWindowIntervals(window AccessWindow) [][2]int {
+	start, ok := clockMinutes(window.StartTime)
+	if !ok {
+		return nil
+	}
+	end, ok := clockMinutes(window.EndTime)
+	if !ok {
+		return nil
+	}
+	if end <= start {
+		end += minutesPerDay
+	}
+
+	var intervals [][2]int
+	for i, day := range accessWindowDays {
+		for _, configured := range window.Days {
+			if strings.EqualFold(configured, day) {
+				intervals = append(intervals, [2]int{i*minutesPerDay + start, i*minutesPerDay + end})
+				break
+			}
+		}
+	}
+	return intervals
+}
+
+// accessWindowsOverlap returns a day on which a and b overlap, if they do.
+// Windows in different time zones are never reported, as whether they overlap
+// changes with daylight saving time.
+func accessWindowsOverlap(a, b AccessWindow) (string, bool) {
+	if a.TimeZone != b.TimeZone {
+		return "", false
+	}
+
+	for _, x := range accessWindowIntervals(a) {
+		for _, y := range accessWindowIntervals(b) {
+			// Compare against the previous and next week too, for windows
+			// running past midnight on Sunday.
+			for _, shift := range []int{-minutesPerWeek, 0, minutesPerWeek} {
+				if x[0] < y[1]+shift && y[0]+shift < x[1] {
+					overlap := x[0]
+					if y[0]+shift > overlap {
+						overlap = y[0] + shift
+					}
+					return accessWindowDays[overlap%minutesPerWeek/minutesPerDay], true
+				}
+			}
+		}
+	}
+	return "", false
+}
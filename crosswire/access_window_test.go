@@ -0,0 +1,58 @@
+package crosswire
+
+import "testing"
+
+func TestAccessWindowsOverlap(t *testing.T) {
+	weekdays := []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"}
+	tests := []struct {
+		name    string
+		a, b    AccessWindow
+		wantDay string
+	}{
+		{
+			name:    "same hours",
+			a:       AccessWindow{Days: weekdays, StartTime: "09:00", EndTime: "17:00", TimeZone: "Europe/Berlin"},
+			b:       AccessWindow{Days: []string{"wednesday"}, StartTime: "16:00", EndTime: "18:00", TimeZone: "Europe/Berlin"},
+			wantDay: "WEDNESDAY",
+		},
+		{
+			name: "adjacent",
+			a:    AccessWindow{Days: weekdays, StartTime: "09:00", EndTime: "12:00", TimeZone: "UTC"},
+			b:    AccessWindow{Days: weekdays, StartTime: "12:00", EndTime: "17:00", TimeZone: "UTC"},
+		},
+		{
+			name: "other days",
+			a:    AccessWindow{Days: weekdays, StartTime: "00:00", EndTime: "24:00", TimeZone: "UTC"},
+			b:    AccessWindow{Days: []string{"SATURDAY", "SUNDAY"}, StartTime: "00:00", EndTime: "24:00", TimeZone: "UTC"},
+		},
+		{
+			name:    "past midnight",
+			a:       AccessWindow{Days: []string{"FRIDAY"}, StartTime: "22:00", EndTime: "06:00", TimeZone: "UTC"},
+			b:       AccessWindow{Days: []string{"SATURDAY"}, StartTime: "05:00", EndTime: "07:00", TimeZone: "UTC"},
+			wantDay: "SATURDAY",
+		},
+		{
+			name:    "past midnight on sunday",
+			a:       AccessWindow{Days: []string{"MONDAY"}, StartTime: "00:00", EndTime: "02:00", TimeZone: "UTC"},
+			b:       AccessWindow{Days: []string{"SUNDAY"}, StartTime: "23:00", EndTime: "01:00", TimeZone: "UTC"},
+			wantDay: "MONDAY",
+		},
+		{
+			name: "other time zones",
+			a:    AccessWindow{Days: weekdays, StartTime: "09:00", EndTime: "17:00", TimeZone: "Europe/Berlin"},
+			b:    AccessWindow{Days: weekdays, StartTime: "09:00", EndTime: "17:00", TimeZone: "America/New_York"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, ok := accessWindowsOverlap(tt.a, tt.b)
+			if ok != (tt.wantDay != "") || day != tt.wantDay {
+				t.Errorf("got %q, %t, want %q", day, ok, tt.wantDay)
+			}
+			if reversed, _ := accessWindowsOverlap(tt.b, tt.a); reversed != day {
+				t.Errorf("reversed got %q, want %q", reversed, day)
+			}
+		})
+	}
+}
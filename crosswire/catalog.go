@@ -0,0 +1,146 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// entitlementCatalog caches the organization's entitlement catalog for the
+// lifetime of a provider instance, so strict validation lists it at most once
+// per Terraform operation.
+type entitlementCatalog struct {
+	mu      sync.Mutex
+	loaded  bool
+	entries map[Entitlement]bool
+}
+
+// loadEntitlementCatalog fetches the catalog unless it has been loaded already.
+func (c *Client) loadEntitlementCatalog(ctx context.Context) error {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.loaded {
+		return nil
+	}
+
+	entitlements, err := c.listEntitlements(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.catalog.entries == nil {
+		c.catalog.entries = map[Entitlement]bool{}
+	}
+	for _, entitlement := range entitlements {
+		c.catalog.entries[entitlement.Entitlement] = true
+	}
+	c.catalog.loaded = true
+	return nil
+}
+
+// addToCatalog records an entitlement that is being created by a
+// crosswire_entitlement resource. Terraform plans a resource's dependencies
+// first, so policies referencing the new entitlement pass strict validation
+// before it exists in Crosswire.
+func (c *Client) addToCatalog(entitlement Entitlement) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.entries == nil {
+		c.catalog.entries = map[Entitlement]bool{}
+	}
+	c.catalog.entries[entitlement] = true
+}
+
+func (c *Client) catalogContains(entitlement Entitlement) bool {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	return c.catalog.entries[entitlement]
+}
+
+// checkCatalogEntitlements adds an error at the element's path for every fully
+// known entitlement in set that is missing from the catalog.
+func (c *Client) checkCatalogEntitlements(set types.Set, p path.Path, diags *diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return
+	}
+
+	for _, element := range set.Elements() {
+		entitlement, ok := knownEntitlement(element)
+		if !ok || c.catalogContains(entitlement) {
+			continue
+		}
+		diags.AddAttributeError(p.AtSetValue(element), "Unknown entitlement", unknownEntitlementDetail(entitlement))
+	}
+}
+
+// checkCatalogConditionTree checks every entitlement of a decoded
+// condition_json tree. Errors are reported on the attribute itself, as
+// elements of a JSON string have no path of their own.
+func (c *Client) checkCatalogConditionTree(condition Condition, p path.Path, diags *diag.Diagnostics) {
+	for _, entitlement := range condition.Entitlements {
+		if !c.catalogContains(entitlement) {
+			diags.AddAttributeError(p, "Unknown entitlement", unknownEntitlementDetail(entitlement))
+		}
+	}
+	for _, subcondition := range condition.Subconditions {
+		c.checkCatalogConditionTree(subcondition, p, diags)
+	}
+}
+
+func unknownEntitlementDetail(entitlement Entitlement) string {
+	return fmt.Sprintf("Entitlement provider=%q subject=%q object=%q does not exist in the Crosswire entitlement catalog. "+
+		"Check it for typos or declare it with a crosswire_entitlement resource. "+
+		"Set strict_validation = false in the provider configuration to skip this check.",
+		entitlement.Provider, entitlement.Subject, entitlement.Object)
+}
+
+// checkCatalogCondition checks the entitlements of condition and of every
+// nested subcondition.
+func (c *Client) checkCatalogCondition(condition types.Object, p path.Path, diags *diag.Diagnostics) {
+	if condition.IsNull() || condition.IsUnknown() {
+		return
+	}
+
+	attributes := condition.Attributes()
+	if entitlements, ok := attributes["entitlements"].(types.Set); ok {
+		c.checkCatalogEntitlements(entitlements, p.AtName("entitlements"), diags)
+	}
+
+	subconditions, ok := attributes["subconditions"].(types.Set)
+	if !ok || subconditions.IsNull() || subconditions.IsUnknown() {
+		return
+	}
+	for _, element := range subconditions.Elements() {
+		if subcondition, ok := element.(types.Object); ok {
+			c.checkCatalogCondition(subcondition, p.AtName("subconditions").AtSetValue(element), diags)
+		}
+	}
+}
+
+// knownEntitlement converts a provider/subject/object object value, reporting
+// false while any part of it is still unknown.
+func knownEntitlement(value attr.Value) (Entitlement, bool) {
+	object, ok := value.(types.Object)
+	if !ok || object.IsNull() || object.IsUnknown() {
+		return Entitlement{}, false
+	}
+
+	var parts [3]string
+	for i, name := range []string{"provider", "subject", "object"} {
+		part, ok := object.Attributes()[name].(types.String)
+		if !ok || part.IsNull() || part.IsUnknown() {
+			return Entitlement{}, false
+		}
+		parts[i] = part.ValueString()
+	}
+
+	return Entitlement{Provider: parts[0], Subject: parts[1], Object: parts[2]}, true
+}
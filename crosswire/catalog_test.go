@@ -0,0 +1,79 @@
+package crosswire
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testEntitlementType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"provider": types.StringType,
+	"subject":  types.StringType,
+	"object":   types.StringType,
+}}
+
+func testEntitlementValue(provider, subject string, object types.String) attr.Value {
+	return types.ObjectValueMust(testEntitlementType.AttrTypes, map[string]attr.Value{
+		"provider": types.StringValue(provider),
+		"subject":  types.StringValue(subject),
+		"object":   object,
+	})
+}
+
+func TestCheckCatalogCondition(t *testing.T) {
+	client := &Client{}
+	client.addToCatalog(Entitlement{Provider: "GITHUB", Subject: "TEAM", Object: "platform"})
+
+	known := testEntitlementValue("GITHUB", "TEAM", types.StringValue("platform"))
+	typo := testEntitlementValue("GITHUB", "TEAM", types.StringValue("platfrom"))
+	pending := testEntitlementValue("GITHUB", "TEAM", types.StringUnknown())
+
+	leafType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"quantifier":   types.StringType,
+		"entitlements": types.SetType{ElemType: testEntitlementType},
+	}}
+	subcondition := types.ObjectValueMust(leafType.AttrTypes, map[string]attr.Value{
+		"quantifier":   types.StringValue("ALL"),
+		"entitlements": types.SetValueMust(testEntitlementType, []attr.Value{known, typo}),
+	})
+	subconditions := types.SetValueMust(leafType, []attr.Value{subcondition})
+	condition := types.ObjectValueMust(map[string]attr.Type{
+		"quantifier":    types.StringType,
+		"entitlements":  types.SetType{ElemType: testEntitlementType},
+		"subconditions": types.SetType{ElemType: leafType},
+	}, map[string]attr.Value{
+		"quantifier":    types.StringValue("ANY"),
+		"entitlements":  types.SetValueMust(testEntitlementType, []attr.Value{known, pending}),
+		"subconditions": subconditions,
+	})
+
+	var diags diag.Diagnostics
+	client.checkCatalogCondition(condition, path.Root("condition"), &diags)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("got %d errors, want 1: %v", diags.ErrorsCount(), diags)
+	}
+	want := path.Root("condition").AtName("subconditions").AtSetValue(subcondition).AtName("entitlements").AtSetValue(typo)
+	if got := diags[0].(diag.DiagnosticWithPath).Path(); !got.Equal(want) {
+		t.Errorf("got error at %s, want %s", got, want)
+	}
+}
+
+func TestCheckCatalogConditionTree(t *testing.T) {
+	client := &Client{}
+	client.addToCatalog(Entitlement{Provider: "OKTA", Subject: "GROUP", Object: "sre"})
+
+	condition := testDeepCondition(5)
+	condition.Entitlements = []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "srr"}}
+
+	var diags diag.Diagnostics
+	client.checkCatalogConditionTree(condition, path.Root("condition_json"), &diags)
+
+	if diags.ErrorsCount() != 1 || !strings.Contains(diags[0].Detail(), `object="srr"`) {
+		t.Fatalf("got %v, want one error for the misspelled entitlement", diags)
+	}
+}
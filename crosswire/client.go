@@ -1,55 +1,310 @@
 package crosswire
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type Policy struct {
-	Owner                string
-	Name                 string
-	Entitlements         []Entitlement
-	Condition            Condition
-	SpecialApprover      *string
-	ApprovalBehavior     *string
-	UserApprovers        []string
-	EntitlementApprovers []Entitlement
-	Ttl                  *int64
+	Owner                  string          `json:"Owner"`
+	Name                   string          `json:"Name"`
+	Entitlements           []Entitlement   `json:"Entitlements"`
+	Condition              Condition       `json:"Condition"`
+	SpecialApprover        *string         `json:"SpecialApprover"`
+	ApprovalBehavior       *string         `json:"ApprovalBehavior"`
+	MinApprovals           *int64          `json:"MinApprovals,omitempty"`
+	UserApprovers          []string        `json:"UserApprovers"`
+	EntitlementApprovers   []Entitlement   `json:"EntitlementApprovers"`
+	GroupApprovers         []string        `json:"GroupApprovers"`
+	ApprovalStages         []ApprovalStage `json:"ApprovalStages,omitempty"`
+	RequireJustification   bool            `json:"RequireJustification"`
+	JustificationMinLength *int64          `json:"JustificationMinLength,omitempty"`
+	TicketPattern          string          `json:"TicketPattern,omitempty"`
+	TicketSystem           string          `json:"TicketSystem,omitempty"`
+	Ttl                    *int64          `json:"Ttl"`
+	DefaultTtl             *int64          `json:"DefaultTtl,omitempty"`
+	AllowExtension         bool            `json:"AllowExtension"`
+	MaxExtensions          *int64          `json:"MaxExtensions,omitempty"`
+	AccessWindows          []AccessWindow  `json:"AccessWindows,omitempty"`
+
+	Id    string `json:"Id"`
+	State string `json:"State"`
+}
+
+// AccessWindow is a recurring period during which grants of a policy can be
+// used. StartTime and EndTime are HH:MM times in TimeZone, and windows ending
+// before they start run past midnight into the next day.
+type AccessWindow struct {
+	Days      []string `json:"Days"`
+	StartTime string   `json:"StartTime"`
+	EndTime   string   `json:"EndTime"`
+	TimeZone  string   `json:"TimeZone"`
+}
 
-	Id    string
-	State string
+// ApprovalStage is one step of a multi-stage approval chain. Requests move to
+// the next stage once a stage has approved them. Policies without stages use
+// the approver fields of Policy as their only stage.
+type ApprovalStage struct {
+	SpecialApprover      *string       `json:"SpecialApprover,omitempty"`
+	ApprovalBehavior     *string       `json:"ApprovalBehavior,omitempty"`
+	MinApprovals         *int64        `json:"MinApprovals,omitempty"`
+	UserApprovers        []string      `json:"UserApprovers"`
+	EntitlementApprovers []Entitlement `json:"EntitlementApprovers"`
+	GroupApprovers       []string      `json:"GroupApprovers"`
 }
 
 type Condition struct {
-	Quantifier    string
-	Entitlements  []Entitlement
-	Subconditions []Condition
+	Quantifier    string        `json:"Quantifier"`
+	Threshold     *int64        `json:"Threshold,omitempty"`
+	Entitlements  []Entitlement `json:"Entitlements"`
+	Subconditions []Condition   `json:"Subconditions"`
 }
 
 type Entitlement struct {
-	Provider, Subject, Object string
+	Provider string `json:"Provider"`
+	Subject  string `json:"Subject"`
+	Object   string `json:"Object"`
+}
+
+// Group is a Crosswire managed group of users, usable as a policy approver.
+// Groups lists the ids of nested groups whose members also belong to it.
+type Group struct {
+	Name        string   `json:"Name"`
+	Description string   `json:"Description"`
+	Members     []string `json:"Members"`
+	Groups      []string `json:"Groups"`
+	Owners      []string `json:"Owners"`
+
+	Id string `json:"Id"`
+}
+
+func (g *Group) validate() error {
+	switch {
+	case g.Id == "":
+		return fmt.Errorf("received group without an Id")
+	case g.Name == "":
+		return fmt.Errorf("received group %s without a Name", g.Id)
+	}
+	return nil
+}
+
+// User is a member of the Crosswire organization.
+type User struct {
+	Id           string `json:"Id"`
+	EmailAddress string `json:"EmailAddress"`
+	Name         string `json:"Name"`
+	Manager      string `json:"Manager"`
+	Department   string `json:"Department"`
+	Active       bool   `json:"Active"`
+}
+
+func (u *User) validate() error {
+	switch {
+	case u.Id == "":
+		return fmt.Errorf("received user without an Id")
+	case u.EmailAddress == "":
+		return fmt.Errorf("received user %s without an EmailAddress", u.Id)
+	}
+	return nil
+}
+
+// EntitlementDefinition is an entitlement registered in the Crosswire
+// catalog, which policies may then grant or reference.
+type EntitlementDefinition struct {
+	Entitlement
+	Description string `json:"Description"`
+	RiskLevel   string `json:"RiskLevel"`
+	Integration string `json:"Integration"`
+
+	Id string `json:"Id"`
+}
+
+func (e *EntitlementDefinition) validate() error {
+	switch {
+	case e.Id == "":
+		return fmt.Errorf("received entitlement without an Id")
+	case e.Provider == "" || e.Subject == "" || e.Object == "":
+		return fmt.Errorf("received entitlement %s without a complete Provider, Subject and Object", e.Id)
+	}
+	return nil
+}
+
+// validate reports the first required field missing from a policy returned
+// by the API.
+func (p *Policy) validate() error {
+	switch {
+	case p.Id == "":
+		return fmt.Errorf("received policy without an Id")
+	case p.Owner == "":
+		return fmt.Errorf("received policy %s without an Owner", p.Id)
+	case p.Name == "":
+		return fmt.Errorf("received policy %s without a Name", p.Id)
+	case p.State == "":
+		return fmt.Errorf("received policy %s without a State", p.Id)
+	}
+	return nil
+}
+
+// responseTrace records the X-Request-Id of the response a body was decoded
+// from so it can be quoted in support requests.
+type responseTrace struct {
+	TraceId string `json:"-"`
+}
+
+func (r *responseTrace) setTraceId(traceId string) {
+	r.TraceId = traceId
+}
+
+type validateResponse struct {
+	responseTrace
+	Success *bool `json:"success"`
+}
+
+type policiesResponse struct {
+	responseTrace
+	Policies map[string]Policy `json:"policies"`
+}
+
+type policyPageResponse struct {
+	responseTrace
+	Policies      []Policy `json:"policies"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+type entitlementPageResponse struct {
+	responseTrace
+	Entitlements  []EntitlementDefinition `json:"entitlements"`
+	NextPageToken string                  `json:"nextPageToken"`
+}
+
+type userLookupRequest struct {
+	EmailAddresses []string `json:"emailAddresses"`
+}
+
+type usersResponse struct {
+	responseTrace
+	Users []User `json:"users"`
+}
+
+type userPageResponse struct {
+	responseTrace
+	Users         []User `json:"users"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ErrNotFound is matched by errors returned from the client when Crosswire
+// has no record of the requested object.
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned when the Crosswire API responds with a non-200 status.
+type APIError struct {
+	StatusCode int
+	TraceId    string
+	Details    string
+}
+
+func (e *APIError) Error() string {
+	if e.TraceId != "" {
+		return fmt.Sprintf("\nHTTP Response Code: %d\nTrace ID: %s\nDetails: %s", e.StatusCode, e.TraceId, e.Details)
+	}
+	return fmt.Sprintf("\nHTTP Response Code: %d\nDetails: %s", e.StatusCode, e.Details)
+}
+
+// Is reports whether the response was a 404 so callers can use
+// errors.Is(err, ErrNotFound).
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
 }
 
 // HostURL - Default API endpoint
 const HostURL string = "https://webhook.crosswire.io"
 
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried.
+	DefaultMaxRetries = 3
+	// DefaultRequestTimeout bounds each individual HTTP attempt.
+	DefaultRequestTimeout = 10 * time.Second
+
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
 // Client -
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
 	Token      string
+
+	// MaxRetries is how many times a request is retried after a transport
+	// error, 429, or 5xx response. Zero disables retries.
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// StrictValidation makes policy plans fail when they reference
+	// entitlements missing from the catalog.
+	StrictValidation bool
+
+	// ValidateUsers makes plans fail when they reference unknown or
+	// deactivated users. NewClient enables it.
+	ValidateUsers bool
+
+	catalog entitlementCatalog
+	users   userDirectory
+}
+
+// ClientOption configures optional Client behavior in NewClient.
+type ClientOption func(*Client)
+
+// WithMaxRetries sets how many times a failed request is retried.
+func WithMaxRetries(maxRetries int) ClientOption {
+	return func(c *Client) {
+		c.MaxRetries = maxRetries
+	}
+}
+
+// WithRequestTimeout sets the timeout applied to each HTTP attempt.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// WithStrictValidation enables plan-time checks against the entitlement catalog.
+func WithStrictValidation(strict bool) ClientOption {
+	return func(c *Client) {
+		c.StrictValidation = strict
+	}
+}
+
+// WithValidateUsers sets whether plans check owners, approvers and group
+// members against Crosswire users.
+func WithValidateUsers(validate bool) ClientOption {
+	return func(c *Client) {
+		c.ValidateUsers = validate
+	}
 }
 
 // NewClient -
-func NewClient(host, token *string) (*Client, error) {
+func NewClient(ctx context.Context, host, token *string, opts ...ClientOption) (*Client, error) {
 	client := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		HostURL:    HostURL,
+		HTTPClient:   &http.Client{Timeout: DefaultRequestTimeout},
+		HostURL:      HostURL,
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: defaultRetryWaitMin,
+		RetryWaitMax: defaultRetryWaitMax,
+
+		ValidateUsers: true,
 	}
 
 	if host != nil {
@@ -58,8 +313,11 @@ func NewClient(host, token *string) (*Client, error) {
 	if token != nil {
 		client.Token = *token
 	}
+	for _, opt := range opts {
+		opt(&client)
+	}
 
-	if success, err := client.Validate(); err != nil {
+	if success, err := client.Validate(ctx); err != nil {
 		return nil, err
 	} else if !success {
 		return nil, fmt.Errorf("client validation failed: ")
@@ -67,190 +325,628 @@ func NewClient(host, token *string) (*Client, error) {
 	return &client, nil
 }
 
-func (c *Client) Validate() (bool, error) {
+func (c *Client) Validate(ctx context.Context) (bool, error) {
 	if c.Token == "" {
 		return false, fmt.Errorf("please enter a token")
 	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/integrations/crosswire_terraform/validate", c.HostURL), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/validate", c.HostURL), nil)
 	if err != nil {
 		return false, err
 	}
 
-	body, err := c.doRequest(req, nil)
-	if err != nil {
+	var body validateResponse
+	if err := c.doRequest(req, nil, &body); err != nil {
 		return false, err
 	}
 
-	if status, ok := body["success"]; ok {
-		if success, ok := status.(bool); ok {
-			return success, nil
+	if body.Success == nil {
+		err := "Received invalid response body: missing success\n"
+		if body.TraceId != "" {
+			err = fmt.Sprintf("%sPlease use reference ID %s when requesting support.\n", err, body.TraceId)
 		}
-	} else {
-		err := fmt.Sprintf("Received invalid response body: %+v\n", body)
-		if trace, ok := body["traceId"]; ok {
-			err = fmt.Sprintf("%sPlease use reference ID %s when requesting support.\n", err, trace)
-		}
-		return false, fmt.Errorf(err)
+		return false, errors.New(err)
 	}
 
-	return false, nil
+	return *body.Success, nil
 }
 
-func (c *Client) createPolicy(policy Policy) (*Policy, error) {
+func (c *Client) createPolicy(ctx context.Context, policy Policy) (*Policy, error) {
 	rb, err := json.Marshal(policy)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/integrations/crosswire_terraform/policy", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/integrations/crosswire_terraform/policy", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req, nil)
-	if err != nil {
+	var body Policy
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
 		return nil, err
 	}
 
-	return convertPolicy(body), nil
+	return &body, nil
 }
 
-func (c *Client) getPolicy(label string) (*Policy, error) {
-	if c.Token == "" {
-		return nil, fmt.Errorf("please enter a token")
+func (c *Client) updatePolicy(ctx context.Context, policy Policy) (*Policy, error) {
+	if policy.Id == "" {
+		return nil, fmt.Errorf("cannot update a policy without an id")
 	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/integrations/crosswire_terraform/policy?label=%s", c.HostURL, url.QueryEscape(label)), nil)
+	rb, err := json.Marshal(policy)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/integrations/crosswire_terraform/policy?id=%s", c.HostURL, url.QueryEscape(policy.Id)), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
 
-	var foundPolicy *Policy
-	if policies, ok := body["policies"]; ok {
-		if policiesMap, ok := policies.(map[string]any); ok {
-			if len(policiesMap) > 1 {
-				return nil, fmt.Errorf("found %d policies. Expected 1 policy", len(policiesMap))
-			}
-			for id, policy := range policiesMap {
-				if policyMap, ok := policy.(map[string]any); ok && id == policyMap["Id"].(string) {
-					foundPolicy = convertPolicy(policyMap)
-				}
-			}
-		}
+	var body Policy
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
+		return nil, err
 	}
 
-	return foundPolicy, nil
+	return &body, nil
 }
 
-func convertPolicy(policyMap map[string]any) *Policy {
-	policy := &Policy{
-		Id:                   policyMap["Id"].(string),
-		Owner:                policyMap["Owner"].(string),
-		Name:                 policyMap["Name"].(string),
-		State:                policyMap["State"].(string),
-		Entitlements:         entMapSliceConverter(policyMap["Entitlements"].([]any)),
-		Condition:            conditionConverter(policyMap["Condition"].(map[string]any)),
-		SpecialApprover:      ToPointer(policyMap["SpecialApprover"].(string)),
-		ApprovalBehavior:     ToPointer(policyMap["ApprovalBehavior"].(string)),
-		UserApprovers:        interfaceSliceToStrings(policyMap["UserApprovers"].([]any)),
-		EntitlementApprovers: entMapSliceConverter(policyMap["EntitlementApprovers"].([]any)),
+func (c *Client) deletePolicy(ctx context.Context, id, revocationBehavior string) error {
+	if id == "" {
+		return fmt.Errorf("cannot delete a policy without an id")
 	}
-	if ttl, ok := policyMap["Ttl"].(int64); ok && ttl > 0 {
-		policy.Ttl = &ttl
+
+	query := url.Values{}
+	query.Set("id", id)
+	query.Set("revocationBehavior", revocationBehavior)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/integrations/crosswire_terraform/policy?%s", c.HostURL, query.Encode()), nil)
+	if err != nil {
+		return err
 	}
 
-	return policy
+	return c.doRequest(req, nil, nil)
 }
 
-func conditionConverter(input map[string]any) (cond Condition) {
-	if rawQuantifier, ok := input["Quantifier"]; ok {
-		if quantifier, ok := rawQuantifier.(string); ok {
-			cond.Quantifier = quantifier
+// getPolicy returns the policy with the given id. The API searches policies
+// by id or name, so any result that is not the policy with that id is treated
+// as not found.
+func (c *Client) getPolicy(ctx context.Context, id string) (*Policy, error) {
+	policies, err := c.findPolicies(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, ok := policies[id]
+	if !ok {
+		return nil, fmt.Errorf("policy %q: %w", id, ErrNotFound)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	if policy.Id != id {
+		return nil, fmt.Errorf("policy %q: %w", id, ErrNotFound)
+	}
+	return &policy, nil
+}
+
+// getPolicyByName returns the only policy whose name is exactly name.
+func (c *Client) getPolicyByName(ctx context.Context, name string) (*Policy, error) {
+	policies, err := c.findPolicies(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Policy
+	for _, policy := range policies {
+		if policy.Name == name {
+			found = append(found, policy)
 		}
 	}
-	if rawEnts, ok := input["Entitlements"]; ok {
-		if ents, ok := rawEnts.([]any); ok {
-			cond.Entitlements = entMapSliceConverter(ents)
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("policy named %q: %w", name, ErrNotFound)
+	case 1:
+		if err := found[0].validate(); err != nil {
+			return nil, err
 		}
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("found %d policies named %q. Expected 1 policy", len(found), name)
 	}
-	if rawConds, ok := input["Subconditions"]; ok {
-		if conds, ok := rawConds.([]any); ok {
-			for _, rawCond := range conds {
-				if subcondition, ok := rawCond.(map[string]any); ok {
-					cond.Subconditions = append(cond.Subconditions, conditionConverter(subcondition))
-				}
-			}
+}
+
+func (c *Client) createEntitlement(ctx context.Context, entitlement EntitlementDefinition) (*EntitlementDefinition, error) {
+	return c.sendEntitlement(ctx, "POST", fmt.Sprintf("%s/integrations/crosswire_terraform/entitlement", c.HostURL), entitlement)
+}
+
+func (c *Client) updateEntitlement(ctx context.Context, entitlement EntitlementDefinition) (*EntitlementDefinition, error) {
+	if entitlement.Id == "" {
+		return nil, fmt.Errorf("cannot update an entitlement without an id")
+	}
+	return c.sendEntitlement(ctx, "PUT", fmt.Sprintf("%s/integrations/crosswire_terraform/entitlement?id=%s", c.HostURL, url.QueryEscape(entitlement.Id)), entitlement)
+}
+
+func (c *Client) sendEntitlement(ctx context.Context, method, endpoint string, entitlement EntitlementDefinition) (*EntitlementDefinition, error) {
+	rb, err := json.Marshal(entitlement)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	var body EntitlementDefinition
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (c *Client) getEntitlement(ctx context.Context, id string) (*EntitlementDefinition, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/entitlement?id=%s", c.HostURL, url.QueryEscape(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body EntitlementDefinition
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (c *Client) deleteEntitlement(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("cannot delete an entitlement without an id")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/integrations/crosswire_terraform/entitlement?id=%s", c.HostURL, url.QueryEscape(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.doRequest(req, nil, nil)
+}
+
+// pageSize is how many policies, entitlements or users are requested per
+// page when listing.
+const pageSize = 100
+
+// lookupUsers returns the users matching emails in a single request. Emails
+// without a matching user are left out of the result.
+func (c *Client) lookupUsers(ctx context.Context, emails []string) ([]User, error) {
+	rb, err := json.Marshal(userLookupRequest{EmailAddresses: emails})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/integrations/crosswire_terraform/users/lookup", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	var body usersResponse
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+
+	for _, user := range body.Users {
+		if err := user.validate(); err != nil {
+			return nil, err
 		}
 	}
-	return
+	return body.Users, nil
 }
 
-func entMapSliceConverter(input []any) (output []Entitlement) {
-	for _, item := range input {
-		output = append(output, entMapToEnt(item.(map[string]any)))
+func (c *Client) createGroup(ctx context.Context, group Group) (*Group, error) {
+	return c.sendGroup(ctx, "POST", fmt.Sprintf("%s/integrations/crosswire_terraform/group", c.HostURL), group)
+}
+
+func (c *Client) updateGroup(ctx context.Context, group Group) (*Group, error) {
+	if group.Id == "" {
+		return nil, fmt.Errorf("cannot update a group without an id")
 	}
-	return
+	return c.sendGroup(ctx, "PUT", fmt.Sprintf("%s/integrations/crosswire_terraform/group?id=%s", c.HostURL, url.QueryEscape(group.Id)), group)
 }
 
-func entMapToEnt(input map[string]any) Entitlement {
-	return Entitlement{
-		Provider: input["Provider"].(string),
-		Subject:  input["Subject"].(string),
-		Object:   input["Object"].(string),
+func (c *Client) sendGroup(ctx context.Context, method, endpoint string, group Group) (*Group, error) {
+	rb, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	var body Group
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
+		return nil, err
 	}
+
+	return &body, nil
 }
 
-func interfaceSliceToStrings(input []any) (output []string) {
-	for _, item := range input {
-		output = append(output, item.(string))
+func (c *Client) getGroup(ctx context.Context, id string) (*Group, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/group?id=%s", c.HostURL, url.QueryEscape(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body Group
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
+		return nil, err
 	}
-	return
+
+	return &body, nil
 }
 
-func (c *Client) doRequest(req *http.Request, authToken *string) (map[string]any, error) {
-	var jsonData map[string]any
+func (c *Client) deleteGroup(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("cannot delete a group without an id")
+	}
 
-	req.Header.Set("Token", c.Token)
-	if authToken != nil {
-		req.Header.Set("Token", *authToken)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/integrations/crosswire_terraform/group?id=%s", c.HostURL, url.QueryEscape(id)), nil)
+	if err != nil {
+		return err
 	}
 
-	res, err := c.HTTPClient.Do(req)
+	return c.doRequest(req, nil, nil)
+}
+
+// getUser returns the user with the given Crosswire user id.
+func (c *Client) getUser(ctx context.Context, id string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/user?id=%s", c.HostURL, url.QueryEscape(id)), nil)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	body, err := io.ReadAll(res.Body)
+	var body User
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+	if err := body.validate(); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+// getUserByEmail returns the user with the given email address, ignoring case.
+func (c *Client) getUserByEmail(ctx context.Context, email string) (*User, error) {
+	users, err := c.lookupUsers(ctx, []string{email})
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		if header, ok := res.Header["X-Request-Id"]; ok && len(header) == 1 {
-			return nil, fmt.Errorf("\nHTTP Response Code: %d\nTrace ID: %s\nDetails: %s", res.StatusCode, header[0], string(body))
-		} else {
-			return nil, fmt.Errorf("\nHTTP Response Code: %d\nDetails: %s", res.StatusCode, string(body))
+	for i := range users {
+		if strings.EqualFold(users[i].EmailAddress, email) {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no user with email address %q: %w", email, ErrNotFound)
+}
+
+// listUsers returns every user in the organization, following pagination
+// until the API stops returning a next page token.
+func (c *Client) listUsers(ctx context.Context) ([]User, error) {
+	var users []User
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("pageSize", strconv.Itoa(pageSize))
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/users?%s", c.HostURL, query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var body userPageResponse
+		if err := c.doRequest(req, nil, &body); err != nil {
+			return nil, err
+		}
+
+		for _, user := range body.Users {
+			if err := user.validate(); err != nil {
+				return nil, err
+			}
+		}
+		users = append(users, body.Users...)
+
+		if body.NextPageToken == "" {
+			return users, nil
+		}
+		if body.NextPageToken == pageToken {
+			return nil, fmt.Errorf("received the same page token %q twice while listing users", pageToken)
+		}
+		pageToken = body.NextPageToken
+	}
+}
+
+// listEntitlements returns the organization's entitlement catalog, following
+// pagination until the API stops returning a next page token.
+func (c *Client) listEntitlements(ctx context.Context) ([]EntitlementDefinition, error) {
+	var entitlements []EntitlementDefinition
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("pageSize", strconv.Itoa(pageSize))
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/entitlements?%s", c.HostURL, query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var body entitlementPageResponse
+		if err := c.doRequest(req, nil, &body); err != nil {
+			return nil, err
+		}
+
+		for _, entitlement := range body.Entitlements {
+			if err := entitlement.validate(); err != nil {
+				return nil, err
+			}
+		}
+		entitlements = append(entitlements, body.Entitlements...)
+
+		if body.NextPageToken == "" {
+			return entitlements, nil
+		}
+		if body.NextPageToken == pageToken {
+			return nil, fmt.Errorf("received the same page token %q twice while listing entitlements", pageToken)
+		}
+		pageToken = body.NextPageToken
+	}
+}
+
+// listPolicies returns every policy in the organization, following
+// pagination until the API stops returning a next page token.
+func (c *Client) listPolicies(ctx context.Context) ([]Policy, error) {
+	var policies []Policy
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("pageSize", strconv.Itoa(pageSize))
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/policies?%s", c.HostURL, query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var body policyPageResponse
+		if err := c.doRequest(req, nil, &body); err != nil {
+			return nil, err
+		}
+
+		for _, policy := range body.Policies {
+			if err := policy.validate(); err != nil {
+				return nil, err
+			}
+		}
+		policies = append(policies, body.Policies...)
+
+		if body.NextPageToken == "" {
+			return policies, nil
+		}
+		if body.NextPageToken == pageToken {
+			return nil, fmt.Errorf("received the same page token %q twice while listing policies", pageToken)
 		}
+		pageToken = body.NextPageToken
+	}
+}
+
+// findPolicies returns every policy matching label, keyed by policy id.
+func (c *Client) findPolicies(ctx context.Context, label string) (map[string]Policy, error) {
+	if c.Token == "" {
+		return nil, fmt.Errorf("please enter a token")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/crosswire_terraform/policy?label=%s", c.HostURL, url.QueryEscape(label)), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &jsonData); err != nil {
-		if header, ok := res.Header["X-Request-Id"]; ok && len(header) == 1 {
-			return nil, fmt.Errorf("\nHTTP Response Code: %d\nTrace ID: %s\nError: %s\nDetails: %s", res.StatusCode, header[0], err.Error(), string(body))
-		} else {
-			return nil, fmt.Errorf("\nHTTP Response Code: %d\nError: %s\nDetails: %s", res.StatusCode, err.Error(), string(body))
+	var body policiesResponse
+	if err := c.doRequest(req, nil, &body); err != nil {
+		return nil, err
+	}
+
+	return body.Policies, nil
+}
+
+// doRequest sends req and decodes a successful JSON response into out, which
+// may be nil when the caller does not need the body. Transport errors and
+// 5xx responses are retried for idempotent methods, and 429 responses are
+// retried for every method, up to c.MaxRetries times.
+func (c *Client) doRequest(req *http.Request, authToken *string, out any) error {
+	req.Header.Set("Token", c.Token)
+	if authToken != nil {
+		req.Header.Set("Token", *authToken)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			req.Body = body
 		}
+
+		res, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if attempt < c.MaxRetries && isIdempotent(req.Method) && req.Context().Err() == nil {
+				if err := c.waitForRetry(req, attempt, nil); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if attempt < c.MaxRetries && shouldRetry(req.Method, res.StatusCode) {
+			if err := c.waitForRetry(req, attempt, res); err != nil {
+				return err
+			}
+			continue
+		}
+
+		return decodeResponse(res, body, out)
 	}
+}
 
+func decodeResponse(res *http.Response, body []byte, out any) error {
+	var traceId string
 	if header, ok := res.Header["X-Request-Id"]; ok && len(header) == 1 {
-		jsonData["traceId"] = header[0]
+		traceId = header[0]
 	}
 
-	return jsonData, nil
+	if res.StatusCode != http.StatusOK {
+		return &APIError{StatusCode: res.StatusCode, TraceId: traceId, Details: string(body)}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(out); err != nil {
+		if traceId != "" {
+			return fmt.Errorf("\nHTTP Response Code: %d\nTrace ID: %s\nError: %s\nDetails: %s", res.StatusCode, traceId, err.Error(), string(body))
+		}
+		return fmt.Errorf("\nHTTP Response Code: %d\nError: %s\nDetails: %s", res.StatusCode, err.Error(), string(body))
+	}
+
+	if traced, ok := out.(interface{ setTraceId(string) }); ok {
+		traced.setTraceId(traceId)
+	}
+
+	return nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a response status is worth retrying. A 429 means
+// the request was not processed, so it is safe to retry regardless of method.
+func shouldRetry(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return statusCode >= 500 && statusCode != http.StatusNotImplemented && isIdempotent(method)
+}
+
+// waitForRetry sleeps before the next attempt, honoring a Retry-After header on
+// res when present and otherwise backing off exponentially with jitter. Waits
+// never exceed RetryWaitMax, and end early when the request is cancelled.
+func (c *Client) waitForRetry(req *http.Request, attempt int, res *http.Response) error {
+	wait, ok := retryAfter(res, c.RetryWaitMax)
+	if !ok {
+		wait = c.backoff(attempt)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.RetryWaitMin << attempt
+	if wait <= 0 || wait > c.RetryWaitMax {
+		wait = c.RetryWaitMax
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Jitter between half and all of the computed wait spreads out retries
+	// from parallel resource operations.
+	half := int64(wait / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryAfter reads the Retry-After header of res, capped at max so a server
+// asking for a long pause does not stall the apply.
+func retryAfter(res *http.Response, max time.Duration) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		// Compare in seconds first, as large values overflow a Duration.
+		if int64(seconds) > int64(max/time.Second) {
+			return max, true
+		}
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		wait = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if wait > max {
+		wait = max
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
 }
@@ -0,0 +1,397 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{HostURL: server.URL, HTTPClient: server.Client(), Token: "token"}
+}
+
+const testPolicyJSON = `{"Id": "%s", "Owner": "user@company.com", "Name": "%s", "State": "ACTIVE",
+	"Entitlements": [], "Condition": {"Quantifier": "ANY"}, "SpecialApprover": "NONE",
+	"ApprovalBehavior": "ANY", "UserApprovers": [], "EntitlementApprovers": []}`
+
+func TestGetPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		label    string
+		wantId   string
+		notFound bool
+		wantErr  bool
+	}{
+		{
+			name:     "404",
+			status:   http.StatusNotFound,
+			body:     `{"message": "no such policy"}`,
+			label:    "abc",
+			notFound: true,
+		},
+		{
+			name:     "empty result",
+			status:   http.StatusOK,
+			body:     `{"policies": {}}`,
+			label:    "abc",
+			notFound: true,
+		},
+		{
+			name:   "single result",
+			status: http.StatusOK,
+			body:   `{"policies": {"abc": ` + fmt.Sprintf(testPolicyJSON, "abc", "one") + `}}`,
+			label:  "abc",
+			wantId: "abc",
+		},
+		{
+			name:   "id match among several",
+			status: http.StatusOK,
+			body: `{"policies": {"abc": ` + fmt.Sprintf(testPolicyJSON, "abc", "one") +
+				`, "def": ` + fmt.Sprintf(testPolicyJSON, "def", "abc") + `}}`,
+			label:  "abc",
+			wantId: "abc",
+		},
+		{
+			name:   "name match",
+			status: http.StatusOK,
+			body: `{"policies": {"abc": ` + fmt.Sprintf(testPolicyJSON, "abc", "shared") +
+				`, "def": ` + fmt.Sprintf(testPolicyJSON, "def", "shared") + `}}`,
+			label:    "shared",
+			notFound: true,
+		},
+		{
+			name:     "single other policy",
+			status:   http.StatusOK,
+			body:     `{"policies": {"def": ` + fmt.Sprintf(testPolicyJSON, "def", "abc") + `}}`,
+			label:    "abc",
+			notFound: true,
+		},
+		{
+			name:     "key does not match id",
+			status:   http.StatusOK,
+			body:     `{"policies": {"abc": ` + fmt.Sprintf(testPolicyJSON, "def", "one") + `}}`,
+			label:    "abc",
+			notFound: true,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    `oops`,
+			label:   "abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			policy, err := client.getPolicy(context.Background(), tt.label)
+			if errors.Is(err, ErrNotFound) != tt.notFound {
+				t.Fatalf("errors.Is(err, ErrNotFound) = %v, want %v (err: %v)", !tt.notFound, tt.notFound, err)
+			}
+			if (err != nil) != (tt.wantErr || tt.notFound) {
+				t.Fatalf("unexpected error state: %v", err)
+			}
+			if err == nil && policy.Id != tt.wantId {
+				t.Errorf("got policy %q, want %q", policy.Id, tt.wantId)
+			}
+		})
+	}
+}
+
+// TestGetPolicyNameCollision checks that a policy named like another policy's
+// id is not mistaken for it.
+func TestGetPolicyNameCollision(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newFakeServer()
+	defer server.Close()
+
+	token := fakeAPIToken
+	client, err := NewClient(ctx, &server.URL, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.createPolicy(ctx, Policy{
+		Owner:        "user@company.com",
+		Name:         "policy-404",
+		Entitlements: []Entitlement{{Provider: "CROSSWIRE", Subject: "CREATE", Object: "ENTITLEMENT"}},
+		Condition:    Condition{Quantifier: "ANY"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policy, err := client.getPolicy(ctx, "policy-404"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("getPolicy(%q) = %v, %v, want ErrNotFound", "policy-404", policy, err)
+	}
+	if policy, err := client.getPolicy(ctx, created.Id); err != nil || policy.Id != created.Id {
+		t.Errorf("getPolicy(%q) = %v, %v, want the created policy", created.Id, policy, err)
+	}
+}
+
+func TestGetPolicyDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantTtl *int64
+		wantErr bool
+	}{
+		{
+			name:    "ttl",
+			body:    `{"policies": {"abc": {"Id": "abc", "Owner": "user@company.com", "Name": "one", "State": "ACTIVE", "Ttl": 3600}}}`,
+			wantTtl: ToPointer(int64(3600)),
+		},
+		{
+			name: "null ttl",
+			body: `{"policies": {"abc": {"Id": "abc", "Owner": "user@company.com", "Name": "one", "State": "ACTIVE", "Ttl": null}}}`,
+		},
+		{
+			name:    "missing id",
+			body:    `{"policies": {"abc": {"Owner": "user@company.com", "Name": "one", "State": "ACTIVE"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong type",
+			body:    `{"policies": {"abc": {"Id": "abc", "Owner": ["user@company.com"], "Name": "one", "State": "ACTIVE"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "fractional ttl",
+			body:    `{"policies": {"abc": {"Id": "abc", "Owner": "user@company.com", "Name": "one", "State": "ACTIVE", "Ttl": 1.5}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			body:    `<html></html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			policy, err := client.getPolicy(context.Background(), "abc")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (policy.Ttl == nil) != (tt.wantTtl == nil) || (policy.Ttl != nil && *policy.Ttl != *tt.wantTtl) {
+				t.Errorf("got ttl %v, want %v", policy.Ttl, tt.wantTtl)
+			}
+		})
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{
+			name: "user",
+			body: `{"Id": "u1", "EmailAddress": "user@company.com", "Name": "User", "Active": true}`,
+		},
+		{
+			name:    "missing id",
+			body:    `{"EmailAddress": "user@company.com", "Name": "User"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing email address",
+			body:    `{"Id": "u1", "Name": "User"}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty object",
+			body:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			user, err := client.getUser(context.Background(), "u1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && user.Id != "u1" {
+				t.Errorf("got user %q, want %q", user.Id, "u1")
+			}
+		})
+	}
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		retryAfter   string
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retries 502 on GET",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries 429 on POST",
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "0",
+			wantAttempts: 2,
+		},
+		{
+			name:         "does not retry 500 on POST",
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusInternalServerError, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry 400",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "gives up after max retries",
+			method:       http.MethodPut,
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != `{"a":1}` {
+					t.Errorf("attempt %d sent body %q", attempts, body)
+				}
+				status := tt.statuses[attempts]
+				attempts++
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{}`))
+			})
+			client.MaxRetries = 2
+			client.RetryWaitMin = time.Millisecond
+			client.RetryWaitMax = 5 * time.Millisecond
+
+			var body io.Reader
+			if tt.method == http.MethodPost {
+				body = strings.NewReader(`{"a":1}`)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, client.HostURL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = client.doRequest(req, nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("doRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOk bool
+	}{
+		{name: "missing"},
+		{name: "seconds", header: "7", want: 7 * time.Second, wantOk: true},
+		{name: "past date", header: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0, wantOk: true},
+		{name: "garbage", header: "soon"},
+		{name: "above maximum", header: "86400", want: time.Minute, wantOk: true},
+		{name: "overflowing seconds", header: "9999999999999", want: time.Minute, wantOk: true},
+		{name: "distant date", header: "Fri, 31 Dec 9999 23:59:59 GMT", want: time.Minute, wantOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				res.Header.Set("Retry-After", tt.header)
+			}
+			got, ok := retryAfter(res, time.Minute)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("retryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestListPolicies(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"policies": [` + fmt.Sprintf(testPolicyJSON, "a", "one") + `], "nextPageToken": "2"}`,
+		"2": `{"policies": [` + fmt.Sprintf(testPolicyJSON, "b", "two") + `, ` + fmt.Sprintf(testPolicyJSON, "c", "three") + `], "nextPageToken": "3"}`,
+		"3": `{"policies": [], "nextPageToken": ""}`,
+	}
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	})
+
+	policies, err := client.listPolicies(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, policy := range policies {
+		ids = append(ids, policy.Id)
+	}
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("got policies %v, want [a b c]", ids)
+	}
+
+	pages["3"] = `{"policies": [], "nextPageToken": "3"}`
+	if _, err := client.listPolicies(context.Background()); err == nil {
+		t.Error("expected an error when the API repeats a page token")
+	}
+}
@@ -0,0 +1,407 @@
+package crosswire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxConditionDepth is the number of levels the nested condition attribute
+// supports, counting the top-level condition. Deeper trees are set through
+// condition_json instead.
+const maxConditionDepth = 3
+
+// conditionQuantifiers are the accepted values of a condition's quantifier.
+// AT_LEAST is the only one taking a threshold.
+var conditionQuantifiers = []string{"ANY", "ALL", "NONE", "AT_LEAST"}
+
+var entitlementObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"provider": types.StringType,
+	"subject":  types.StringType,
+	"object":   types.StringType,
+}}
+
+// conditionObjectType returns the type of a condition at level, matching
+// attributeConditionSchemaV0 and dataSourceConditionSchemaV0. The deepest level
+// has no subconditions attribute, so conditions are converted through
+// types.Object rather than a recursive struct.
+func conditionObjectType(level int) types.ObjectType {
+	attributeTypes := map[string]attr.Type{
+		"quantifier":   types.StringType,
+		"threshold":    types.Int64Type,
+		"entitlements": types.SetType{ElemType: entitlementObjectType},
+	}
+	if level < maxConditionDepth-1 {
+		attributeTypes["subconditions"] = types.SetType{ElemType: conditionObjectType(level + 1)}
+	}
+	return types.ObjectType{AttrTypes: attributeTypes}
+}
+
+// conditionDepth counts the levels of condition, including itself.
+func conditionDepth(condition Condition) int {
+	depth := 0
+	for _, subcondition := range condition.Subconditions {
+		if d := conditionDepth(subcondition); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
+
+// conditionToObjectValue converts condition to the condition attribute at
+// level. Its depth must not exceed maxConditionDepth-level.
+func conditionToObjectValue(condition Condition, level int) types.Object {
+	objectType := conditionObjectType(level)
+	attributes := map[string]attr.Value{
+		"quantifier":   types.StringValue(condition.Quantifier),
+		"threshold":    types.Int64Null(),
+		"entitlements": entitlementsToSetValue(condition.Entitlements),
+	}
+	if condition.Threshold != nil {
+		attributes["threshold"] = types.Int64Value(*condition.Threshold)
+	}
+	if subconditionsType, ok := objectType.AttrTypes["subconditions"].(types.SetType); ok {
+		if len(condition.Subconditions) == 0 {
+			attributes["subconditions"] = types.SetNull(subconditionsType.ElemType)
+		} else {
+			var subconditions []attr.Value
+			for _, subcondition := range condition.Subconditions {
+				subconditions = append(subconditions, conditionToObjectValue(subcondition, level+1))
+			}
+			attributes["subconditions"] = types.SetValueMust(subconditionsType.ElemType, subconditions)
+		}
+	}
+	return types.ObjectValueMust(objectType.AttrTypes, attributes)
+}
+
+func entitlementsToSetValue(entitlements []Entitlement) types.Set {
+	if len(entitlements) == 0 {
+		return types.SetNull(entitlementObjectType)
+	}
+
+	var elements []attr.Value
+	for _, entitlement := range entitlements {
+		elements = append(elements, types.ObjectValueMust(entitlementObjectType.AttrTypes, map[string]attr.Value{
+			"provider": types.StringValue(entitlement.Provider),
+			"subject":  types.StringValue(entitlement.Subject),
+			"object":   types.StringValue(entitlement.Object),
+		}))
+	}
+	return types.SetValueMust(entitlementObjectType, elements)
+}
+
+// conditionFromObjectValue converts a known condition attribute, at any level.
+func conditionFromObjectValue(object types.Object) Condition {
+	var condition Condition
+	if object.IsNull() || object.IsUnknown() {
+		return condition
+	}
+
+	attributes := object.Attributes()
+	if quantifier, ok := attributes["quantifier"].(types.String); ok {
+		condition.Quantifier = quantifier.ValueString()
+	}
+	if threshold, ok := attributes["threshold"].(types.Int64); ok && !threshold.IsNull() && !threshold.IsUnknown() {
+		condition.Threshold = ToPointer(threshold.ValueInt64())
+	}
+	if entitlements, ok := attributes["entitlements"].(types.Set); ok {
+		for _, element := range entitlements.Elements() {
+			if entitlement, ok := knownEntitlement(element); ok {
+				condition.Entitlements = append(condition.Entitlements, entitlement)
+			}
+		}
+	}
+	if subconditions, ok := attributes["subconditions"].(types.Set); ok {
+		for _, element := range subconditions.Elements() {
+			if subcondition, ok := element.(types.Object); ok {
+				condition.Subconditions = append(condition.Subconditions, conditionFromObjectValue(subcondition))
+			}
+		}
+	}
+	return condition
+}
+
+// preserveConditionCase returns condition with the quantifiers spelled as in
+// current, at every level where current matches condition ignoring case. Each
+// subcondition is matched against the equivalent one in current.
+func preserveConditionCase(current types.Object, condition Condition) Condition {
+	if current.IsNull() || current.IsUnknown() {
+		return condition
+	}
+
+	attributes := current.Attributes()
+	if quantifier, ok := attributes["quantifier"].(types.String); ok {
+		condition.Quantifier = preserveCase(quantifier, condition.Quantifier).ValueString()
+	}
+	subconditions, ok := attributes["subconditions"].(types.Set)
+	if !ok || len(condition.Subconditions) == 0 {
+		return condition
+	}
+
+	preserved := make([]Condition, len(condition.Subconditions))
+	for i, subcondition := range condition.Subconditions {
+		preserved[i] = subcondition
+		encoded := conditionToJSON(subcondition)
+		for _, element := range subconditions.Elements() {
+			if object, ok := element.(types.Object); ok && conditionToJSON(conditionFromObjectValue(object)) == encoded {
+				preserved[i] = preserveConditionCase(object, subcondition)
+				break
+			}
+		}
+	}
+	condition.Subconditions = preserved
+	return condition
+}
+
+// conditionJSON is the condition_json encoding of a condition, using the names
+// of the condition attribute. Fields are in alphabetical order, like the
+// output of Terraform's jsonencode.
+type conditionJSON struct {
+	Entitlements  []entitlementJSON `json:"entitlements,omitempty"`
+	Quantifier    string            `json:"quantifier"`
+	Subconditions []conditionJSON   `json:"subconditions,omitempty"`
+	Threshold     *int64            `json:"threshold,omitempty"`
+}
+
+type entitlementJSON struct {
+	Object   string `json:"object"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+// conditionFromJSON decodes condition_json, rejecting unknown keys so typos do
+// not silently drop part of the tree.
+func conditionFromJSON(encoded string) (Condition, error) {
+	decoder := json.NewDecoder(strings.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+
+	var decoded conditionJSON
+	if err := decoder.Decode(&decoded); err != nil {
+		return Condition{}, err
+	}
+	if decoder.More() {
+		return Condition{}, fmt.Errorf("unexpected data after the top-level condition")
+	}
+	return decoded.condition(), nil
+}
+
+func (c conditionJSON) condition() Condition {
+	condition := Condition{Quantifier: c.Quantifier, Threshold: c.Threshold}
+	for _, entitlement := range c.Entitlements {
+		condition.Entitlements = append(condition.Entitlements, Entitlement{
+			Provider: entitlement.Provider,
+			Subject:  entitlement.Subject,
+			Object:   entitlement.Object,
+		})
+	}
+	for _, subcondition := range c.Subconditions {
+		condition.Subconditions = append(condition.Subconditions, subcondition.condition())
+	}
+	return condition
+}
+
+// conditionToJSON encodes condition for condition_json. The output is
+// normalized, so equivalent trees encode identically.
+func conditionToJSON(condition Condition) string {
+	_, encoded := normalizeConditionKey(condition)
+	return encoded
+}
+
+// encodeCondition encodes an already normalized condition.
+func encodeCondition(normalized Condition) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// Encoding strings and slices cannot fail.
+	_ = encoder.Encode(newConditionJSON(normalized))
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func newConditionJSON(condition Condition) conditionJSON {
+	encoded := conditionJSON{Quantifier: condition.Quantifier, Threshold: condition.Threshold}
+	for _, entitlement := range condition.Entitlements {
+		encoded.Entitlements = append(encoded.Entitlements, entitlementJSON{
+			Provider: entitlement.Provider,
+			Subject:  entitlement.Subject,
+			Object:   entitlement.Object,
+		})
+	}
+	for _, subcondition := range condition.Subconditions {
+		encoded.Subconditions = append(encoded.Subconditions, newConditionJSON(subcondition))
+	}
+	return encoded
+}
+
+// normalizeCondition upper cases quantifiers and sorts entitlements and
+// subconditions, which Crosswire treats as sets.
+func normalizeCondition(condition Condition) Condition {
+	normalized, _ := normalizeConditionKey(condition)
+	return normalized
+}
+
+// normalizeConditionKey normalizes condition bottom-up and returns it with its
+// encoding. Subconditions are sorted by the encodings returned for them, so
+// each subtree is only normalized once.
+func normalizeConditionKey(condition Condition) (Condition, string) {
+	normalized := Condition{
+		Quantifier:   strings.ToUpper(condition.Quantifier),
+		Threshold:    condition.Threshold,
+		Entitlements: sortEntitlements(condition.Entitlements),
+	}
+	if len(condition.Subconditions) == 0 {
+		return normalized, encodeCondition(normalized)
+	}
+
+	subconditions := make([]Condition, len(condition.Subconditions))
+	keys := make([]string, len(condition.Subconditions))
+	order := make([]int, len(condition.Subconditions))
+	for i, subcondition := range condition.Subconditions {
+		subconditions[i], keys[i] = normalizeConditionKey(subcondition)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]] < keys[order[j]]
+	})
+	for _, i := range order {
+		normalized.Subconditions = append(normalized.Subconditions, subconditions[i])
+	}
+	return normalized, encodeCondition(normalized)
+}
+
+// sortEntitlements returns a copy of entitlements ordered by provider, subject
+// and object.
+func sortEntitlements(entitlements []Entitlement) []Entitlement {
+	sorted := append([]Entitlement(nil), entitlements...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Provider != sorted[j].Provider {
+			return sorted[i].Provider < sorted[j].Provider
+		}
+		if sorted[i].Subject != sorted[j].Subject {
+			return sorted[i].Subject < sorted[j].Subject
+		}
+		return sorted[i].Object < sorted[j].Object
+	})
+	return sorted
+}
+
+// conditionJSONValue returns the condition_json to store for condition. The
+// current value is kept when it decodes to an equivalent tree, so formatting,
+// key order and element order in the configuration do not cause a diff.
+func conditionJSONValue(current types.String, condition Condition) types.String {
+	encoded := conditionToJSON(condition)
+	if current.IsNull() || current.IsUnknown() {
+		return types.StringValue(encoded)
+	}
+	if decoded, err := conditionFromJSON(current.ValueString()); err == nil && conditionToJSON(decoded) == encoded {
+		return current
+	}
+	return types.StringValue(encoded)
+}
+
+// conditionTreeErrors describes every problem with the shape of condition and
+// its subconditions, which the condition attribute's schema would otherwise
+// catch. location names condition in the messages.
+func conditionTreeErrors(condition Condition, location string, top bool) []string {
+	var problems []string
+
+	valid := false
+	for _, quantifier := range conditionQuantifiers {
+		valid = valid || strings.EqualFold(condition.Quantifier, quantifier)
+	}
+	if !valid {
+		problems = append(problems, fmt.Sprintf("%s: quantifier must be one of %s, got %q",
+			location, strings.Join(conditionQuantifiers, ", "), condition.Quantifier))
+	} else if problem := thresholdProblem(condition.Quantifier, condition.Threshold, len(condition.Entitlements)+len(condition.Subconditions)); problem != "" {
+		problems = append(problems, fmt.Sprintf("%s: %s", location, problem))
+	}
+
+	if !top && len(condition.Entitlements) == 0 && len(condition.Subconditions) == 0 {
+		problems = append(problems, fmt.Sprintf("%s: subconditions must set entitlements or subconditions", location))
+	}
+
+	seen := map[Entitlement]bool{}
+	for i, entitlement := range condition.Entitlements {
+		if entitlement.Provider == "" || entitlement.Subject == "" || entitlement.Object == "" {
+			problems = append(problems, fmt.Sprintf("%s.entitlements[%d]: provider, subject and object are required", location, i))
+			continue
+		}
+		key := Entitlement{
+			Provider: strings.ToUpper(entitlement.Provider),
+			Subject:  strings.ToUpper(entitlement.Subject),
+			Object:   strings.ToUpper(entitlement.Object),
+		}
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("%s.entitlements[%d]: provider=%q subject=%q object=%q is listed more than once, ignoring case",
+				location, i, entitlement.Provider, entitlement.Subject, entitlement.Object))
+		}
+		seen[key] = true
+	}
+
+	for i, subcondition := range condition.Subconditions {
+		problems = append(problems, conditionTreeErrors(subcondition, fmt.Sprintf("%s.subconditions[%d]", location, i), false)...)
+	}
+	return problems
+}
+
+// thresholdProblem describes what is wrong with the threshold of a condition,
+// or returns "" when nothing is. children counts the condition's entitlements
+// and subconditions, and is negative while they are unknown.
+func thresholdProblem(quantifier string, threshold *int64, children int) string {
+	if !strings.EqualFold(quantifier, "AT_LEAST") {
+		if threshold != nil {
+			return fmt.Sprintf("threshold can only be set when quantifier is AT_LEAST, got %s", quantifier)
+		}
+		return ""
+	}
+
+	switch {
+	case threshold == nil:
+		return "threshold is required when quantifier is AT_LEAST"
+	case *threshold < 1:
+		return fmt.Sprintf("threshold must be at least 1, got %d", *threshold)
+	case children >= 0 && *threshold > int64(children):
+		return fmt.Sprintf("threshold is %d but the condition only has %d entitlements and subconditions", *threshold, children)
+	}
+	return ""
+}
+
+// conditionJSONValidator checks that a string decodes to a well formed
+// condition tree.
+type conditionJSONValidator struct{}
+
+var _ validator.String = conditionJSONValidator{}
+
+func (v conditionJSONValidator) Description(ctx context.Context) string {
+	return "value must be a JSON encoded condition with a valid quantifier at every level"
+}
+
+func (v conditionJSONValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v conditionJSONValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	condition, err := conditionFromJSON(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid condition_json",
+			"Could not decode the condition: "+err.Error(),
+		)
+		return
+	}
+
+	for _, problem := range conditionTreeErrors(condition, "condition", true) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid condition_json", problem+".")
+	}
+}
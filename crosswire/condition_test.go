@@ -0,0 +1,249 @@
+package crosswire
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testDeepCondition nests a single entitlement depth levels deep.
+func testDeepCondition(depth int) Condition {
+	condition := Condition{
+		Quantifier:   "ANY",
+		Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "sre"}},
+	}
+	for i := 1; i < depth; i++ {
+		condition = Condition{Quantifier: "ALL", Subconditions: []Condition{condition}}
+	}
+	return condition
+}
+
+func TestConditionObjectValue(t *testing.T) {
+	for depth := 1; depth <= maxConditionDepth; depth++ {
+		condition := testDeepCondition(depth)
+		condition.Quantifier = "AT_LEAST"
+		condition.Threshold = ToPointer(int64(1))
+		got := conditionFromObjectValue(conditionToObjectValue(condition, 0))
+		if conditionToJSON(got) != conditionToJSON(condition) {
+			t.Errorf("depth %d: got %s, want %s", depth, conditionToJSON(got), conditionToJSON(condition))
+		}
+		if conditionDepth(got) != depth {
+			t.Errorf("depth %d: got depth %d", depth, conditionDepth(got))
+		}
+	}
+}
+
+func TestConditionFromJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "nested",
+			encoded: `{"quantifier":"all","subconditions":[{"quantifier":"ANY","entitlements":[{"provider":"OKTA","subject":"GROUP","object":"b"},{"provider":"OKTA","subject":"GROUP","object":"a"}]}]}`,
+			want:    `{"quantifier":"ALL","subconditions":[{"entitlements":[{"object":"a","provider":"OKTA","subject":"GROUP"},{"object":"b","provider":"OKTA","subject":"GROUP"}],"quantifier":"ANY"}]}`,
+		},
+		{
+			name:    "threshold",
+			encoded: `{"quantifier":"AT_LEAST","threshold":2,"subconditions":[{"quantifier":"NONE","entitlements":[{"provider":"OKTA","subject":"GROUP","object":"contractors"}]}]}`,
+			want:    `{"quantifier":"AT_LEAST","subconditions":[{"entitlements":[{"object":"contractors","provider":"OKTA","subject":"GROUP"}],"quantifier":"NONE"}],"threshold":2}`,
+		},
+		{name: "unknown key", encoded: `{"quantifier":"ANY","subcondition":[]}`, wantErr: true},
+		{name: "not an object", encoded: `["ANY"]`, wantErr: true},
+		{name: "trailing data", encoded: `{"quantifier":"ANY"} {}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := conditionFromJSON(tt.encoded)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && conditionToJSON(condition) != tt.want {
+				t.Errorf("got %s, want %s", conditionToJSON(condition), tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionJSONValue(t *testing.T) {
+	condition := Condition{
+		Quantifier: "ANY",
+		Subconditions: []Condition{
+			{Quantifier: "ALL", Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "b"}}},
+			{Quantifier: "ALL", Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "a"}}},
+		},
+	}
+	reordered := types.StringValue(`{
+  "quantifier": "any",
+  "subconditions": [
+    {"quantifier": "ALL", "entitlements": [{"provider": "OKTA", "subject": "GROUP", "object": "a"}]},
+    {"quantifier": "ALL", "entitlements": [{"provider": "OKTA", "subject": "GROUP", "object": "b"}]}
+  ]
+}`)
+	changed := types.StringValue(`{"quantifier":"ANY","entitlements":[{"provider":"OKTA","subject":"GROUP","object":"a"}]}`)
+	normalized := types.StringValue(conditionToJSON(condition))
+
+	tests := []struct {
+		name    string
+		current types.String
+		want    types.String
+	}{
+		{"null", types.StringNull(), normalized},
+		{"equivalent", reordered, reordered},
+		{"changed", changed, normalized},
+		{"invalid", types.StringValue("{"), normalized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionJSONValue(tt.current, condition); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreserveConditionCase(t *testing.T) {
+	entitlement := func(object string) []Entitlement {
+		return []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: object}}
+	}
+	current := conditionToObjectValue(Condition{
+		Quantifier: "any",
+		Subconditions: []Condition{
+			{Quantifier: "all", Entitlements: entitlement("a")},
+			{Quantifier: "none", Entitlements: entitlement("b")},
+			{Quantifier: "ALL", Subconditions: []Condition{{Quantifier: "any", Entitlements: entitlement("c")}}},
+		},
+	}, 0)
+	condition := Condition{
+		Quantifier: "ANY",
+		Subconditions: []Condition{
+			{Quantifier: "ALL", Subconditions: []Condition{{Quantifier: "ANY", Entitlements: entitlement("c")}}},
+			{Quantifier: "NONE", Entitlements: entitlement("changed")},
+			{Quantifier: "ALL", Entitlements: entitlement("a")},
+		},
+	}
+
+	got := preserveConditionCase(current, condition)
+	quantifiers := []string{
+		got.Quantifier,
+		got.Subconditions[0].Quantifier,
+		got.Subconditions[0].Subconditions[0].Quantifier,
+		got.Subconditions[1].Quantifier,
+		got.Subconditions[2].Quantifier,
+	}
+	if want := "any ALL any NONE all"; strings.Join(quantifiers, " ") != want {
+		t.Errorf("got quantifiers %q, want %q", strings.Join(quantifiers, " "), want)
+	}
+	if condition.Subconditions[0].Subconditions[0].Quantifier != "ANY" {
+		t.Error("preserveConditionCase modified its argument")
+	}
+	if got := preserveConditionCase(types.ObjectNull(conditionObjectType(0).AttrTypes), condition); conditionToJSON(got) != conditionToJSON(condition) || got.Quantifier != "ANY" {
+		t.Errorf("got %s for a null current value", conditionToJSON(got))
+	}
+}
+
+// testWideCondition builds a complete tree with two subconditions per level,
+// in reverse order when reversed is set.
+func testWideCondition(depth int, reversed bool) Condition {
+	if depth == 1 {
+		return testDeepCondition(1)
+	}
+	first := testWideCondition(depth-1, reversed)
+	second := Condition{Quantifier: "none", Subconditions: []Condition{testWideCondition(depth-1, reversed)}}
+	if reversed {
+		first, second = second, first
+	}
+	return Condition{Quantifier: "all", Subconditions: []Condition{first, second}}
+}
+
+func TestNormalizeConditionWideTree(t *testing.T) {
+	// Sorting on keys computed while normalizing keeps this fast; encoding
+	// subtrees inside the comparator took exponential time.
+	got := conditionToJSON(testWideCondition(12, false))
+	if want := conditionToJSON(testWideCondition(12, true)); got != want {
+		t.Error("subcondition order changed the normalized condition")
+	}
+	if strings.Contains(got, `"all"`) || strings.Contains(got, `"none"`) {
+		t.Error("quantifiers were not upper cased")
+	}
+}
+
+func TestConditionTreeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		want      []string
+	}{
+		{
+			name:      "deep",
+			condition: testDeepCondition(8),
+		},
+		{
+			name:      "empty top-level condition",
+			condition: Condition{Quantifier: "any"},
+		},
+		{
+			name: "invalid quantifier",
+			condition: Condition{Quantifier: "ALL", Subconditions: []Condition{
+				{Quantifier: "SOME", Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "a"}}},
+			}},
+			want: []string{`condition.subconditions[0]: quantifier must be one of ANY, ALL, NONE, AT_LEAST, got "SOME"`},
+		},
+		{
+			name: "at least",
+			condition: Condition{Quantifier: "at_least", Threshold: ToPointer(int64(2)), Entitlements: []Entitlement{
+				{Provider: "OKTA", Subject: "GROUP", Object: "a"},
+				{Provider: "OKTA", Subject: "GROUP", Object: "b"},
+			}},
+		},
+		{
+			name:      "at least without threshold",
+			condition: Condition{Quantifier: "AT_LEAST", Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "a"}}},
+			want:      []string{"condition: threshold is required when quantifier is AT_LEAST"},
+		},
+		{
+			name:      "threshold above children",
+			condition: Condition{Quantifier: "AT_LEAST", Threshold: ToPointer(int64(2)), Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "a"}}},
+			want:      []string{"condition: threshold is 2 but the condition only has 1 entitlements and subconditions"},
+		},
+		{
+			name:      "threshold without at least",
+			condition: Condition{Quantifier: "NONE", Threshold: ToPointer(int64(1)), Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "a"}}},
+			want:      []string{"condition: threshold can only be set when quantifier is AT_LEAST, got NONE"},
+		},
+		{
+			name:      "empty subcondition",
+			condition: Condition{Quantifier: "ALL", Subconditions: []Condition{{Quantifier: "ANY"}}},
+			want:      []string{"condition.subconditions[0]: subconditions must set entitlements or subconditions"},
+		},
+		{
+			name: "incomplete entitlement",
+			condition: Condition{Quantifier: "ANY", Entitlements: []Entitlement{
+				{Provider: "OKTA", Subject: "GROUP"},
+			}},
+			want: []string{"condition.entitlements[0]: provider, subject and object are required"},
+		},
+		{
+			name: "duplicate entitlement",
+			condition: Condition{Quantifier: "ANY", Entitlements: []Entitlement{
+				{Provider: "OKTA", Subject: "GROUP", Object: "a"},
+				{Provider: "okta", Subject: "group", Object: "A"},
+			}},
+			want: []string{`condition.entitlements[1]: provider="okta" subject="group" object="A" is listed more than once, ignoring case`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conditionTreeErrors(tt.condition, "condition", true)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
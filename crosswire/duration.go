@@ -0,0 +1,166 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = DurationType{}
+var _ xattr.TypeWithValidate = DurationType{}
+var _ basetypes.StringValuable = DurationValue{}
+
+// DurationType is a string attribute holding a human readable duration such
+// as "90m", "4h" or "7d". Invalid durations are reported when Terraform
+// validates the configuration.
+type DurationType struct {
+	basetypes.StringType
+}
+
+func (t DurationType) Equal(o attr.Type) bool {
+	other, ok := o.(DurationType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t DurationType) String() string {
+	return "DurationType"
+}
+
+func (t DurationType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DurationValue{StringValue: in}, nil
+}
+
+func (t DurationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return DurationValue{StringValue: stringValue}, nil
+}
+
+func (t DurationType) ValueType(ctx context.Context) attr.Value {
+	return DurationValue{}
+}
+
+func (t DurationType) Validate(ctx context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if in.Type() == nil || !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(p, "Invalid duration", "Could not read the duration: "+err.Error())
+		return diags
+	}
+	if _, err := parseDuration(value); err != nil {
+		diags.AddAttributeError(p, "Invalid duration", err.Error())
+	}
+	return diags
+}
+
+// DurationValue is the value of a DurationType attribute.
+type DurationValue struct {
+	basetypes.StringValue
+}
+
+func DurationNull() DurationValue {
+	return DurationValue{StringValue: basetypes.NewStringNull()}
+}
+
+func DurationUnknown() DurationValue {
+	return DurationValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func NewDurationValue(value string) DurationValue {
+	return DurationValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v DurationValue) Equal(o attr.Value) bool {
+	other, ok := o.(DurationValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v DurationValue) Type(ctx context.Context) attr.Type {
+	return DurationType{}
+}
+
+// Seconds returns the duration in whole seconds, reporting false while the
+// value is null, unknown or invalid.
+func (v DurationValue) Seconds() (int64, bool) {
+	if v.IsNull() || v.IsUnknown() {
+		return 0, false
+	}
+	duration, err := parseDuration(v.ValueString())
+	if err != nil {
+		return 0, false
+	}
+	return int64(duration / time.Second), true
+}
+
+// durationDays matches a leading number of days, which time.ParseDuration
+// does not support.
+var durationDays = regexp.MustCompile(`^(\d+)d`)
+
+// parseDuration parses Go durations such as "1h30m", plus a leading number of
+// days such as "7d" or "1d12h".
+func parseDuration(value string) (time.Duration, error) {
+	var days time.Duration
+	if match := durationDays.FindStringSubmatch(value); match != nil {
+		n, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		value = value[len(match[0]):]
+		if value == "" {
+			return days, nil
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, expected a number followed by a unit such as 90m, 4h or 7d", value)
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("invalid duration %q, durations are counted in whole seconds", value)
+	}
+	return days + duration, nil
+}
+
+// formatDuration writes seconds in the largest unit dividing it evenly.
+func formatDuration(seconds int64) string {
+	for _, unit := range []struct {
+		suffix  string
+		seconds int64
+	}{{"d", 24 * 60 * 60}, {"h", 60 * 60}, {"m", 60}} {
+		if seconds != 0 && seconds%unit.seconds == 0 {
+			return fmt.Sprintf("%d%s", seconds/unit.seconds, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// durationValue returns the value to store for seconds read from Crosswire,
+// keeping the current spelling when it is the same duration so "240m" does
+// not plan a change to "4h".
+func durationValue(current DurationValue, seconds int64) DurationValue {
+	if currentSeconds, ok := current.Seconds(); ok && currentSeconds == seconds {
+		return current
+	}
+	return NewDurationValue(formatDuration(seconds))
+}
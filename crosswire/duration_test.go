@@ -0,0 +1,105 @@
+package crosswire
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "90m", want: 90 * time.Minute},
+		{value: "4h", want: 4 * time.Hour},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "1d12h", want: 36 * time.Hour},
+		{value: "3600", wantErr: true},
+		{value: "1.5s", wantErr: true},
+		{value: "1w", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		want    string
+	}{
+		{0, "0s"},
+		{45, "45s"},
+		{5400, "90m"},
+		{14400, "4h"},
+		{90000, "25h"},
+		{604800, "7d"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.seconds); got != tt.want {
+			t.Errorf("formatDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		current DurationValue
+		seconds int64
+		want    DurationValue
+	}{
+		{"null", DurationNull(), 14400, NewDurationValue("4h")},
+		{"same duration", NewDurationValue("240m"), 14400, NewDurationValue("240m")},
+		{"changed", NewDurationValue("240m"), 3600, NewDurationValue("1h")},
+		{"invalid", NewDurationValue("forever"), 3600, NewDurationValue("1h")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := durationValue(tt.current, tt.seconds); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationTypeValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		value tftypes.Value
+		want  string
+	}{
+		{"valid", tftypes.NewValue(tftypes.String, "4h"), ""},
+		{"null", tftypes.NewValue(tftypes.String, nil), ""},
+		{"unknown", tftypes.NewValue(tftypes.String, tftypes.UnknownValue), ""},
+		{"invalid", tftypes.NewValue(tftypes.String, "4 hours"), "Invalid duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := DurationType{}.Validate(context.Background(), tt.value, path.Root("max_ttl"))
+			if got := diagnosticSummaries(diags); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &EntitlementResource{}
+var _ resource.ResourceWithConfigure = &EntitlementResource{}
+var _ resource.ResourceWithImportState = &EntitlementResource{}
+var _ resource.ResourceWithModifyPlan = &EntitlementResource{}
+
+// defaultEntitlementTimeout applies to each operation when no timeouts block
+// is set.
+const defaultEntitlementTimeout = 5 * time.Minute
+
+func NewEntitlementResource() resource.Resource {
+	return &EntitlementResource{}
+}
+
+// EntitlementResource defines the resource implementation.
+type EntitlementResource struct {
+	client *Client
+}
+
+// EntitlementResourceModel describes the resource data model.
+type EntitlementResourceModel struct {
+	Provider    types.String   `tfsdk:"provider_name"`
+	Subject     types.String   `tfsdk:"subject"`
+	Object      types.String   `tfsdk:"object"`
+	Description types.String   `tfsdk:"description"`
+	RiskLevel   types.String   `tfsdk:"risk_level"`
+	Integration types.String   `tfsdk:"integration"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+func (e *EntitlementResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlement"
+}
+
+func (e *EntitlementResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers an entitlement in the Crosswire catalog. Policies can reference its `provider_name`, `subject` and `object` attributes instead of repeating the tuple.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "System granting the entitlement, e.g. okta or aws. This is the `provider` of the tuple policies reference.",
+			},
+			"subject": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Kind of access within the provider, e.g. group_membership.",
+			},
+			"object": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Specific resource access is granted to, e.g. a group name.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Human readable description shown to users requesting access.",
+			},
+			"risk_level": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					StringDefault("LOW"),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("LOW", "MEDIUM", "HIGH", "CRITICAL"),
+				},
+				Description: "One of LOW, MEDIUM, HIGH or CRITICAL. Defaults to LOW.",
+			},
+			"integration": schema.StringAttribute{
+				Optional:    true,
+				Description: "Crosswire integration that owns and provisions the entitlement.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Crosswire entitlement id",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp Terraform received the entitlement's latest update",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan registers the planned entitlement with the client's catalog so
+// policies that reference it pass strict_validation in the same plan.
+func (e *EntitlementResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || e.client == nil {
+		return
+	}
+
+	var data EntitlementResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, part := range []types.String{data.Provider, data.Subject, data.Object} {
+		if part.IsUnknown() {
+			return
+		}
+	}
+	e.client.addToCatalog(entitlementFromModelConverter(data).Entitlement)
+}
+
+func (e *EntitlementResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *EntitlementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data EntitlementResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultEntitlementTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := e.client.createEntitlement(ctx, entitlementFromModelConverter(data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating entitlement",
+			"Could not create entitlement, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	entitlementToModelConverter(created, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (e *EntitlementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state EntitlementResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultEntitlementTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	entitlement, err := e.client.getEntitlement(ctx, state.Id.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Entitlement no longer exists in Crosswire, removing from state", map[string]any{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Entitlement",
+			"Could not read entitlement "+state.Id.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	entitlementToModelConverter(entitlement, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (e *EntitlementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data EntitlementResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultEntitlementTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	entitlement := entitlementFromModelConverter(data)
+	entitlement.Id = id.ValueString()
+
+	updated, err := e.client.updateEntitlement(ctx, entitlement)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating entitlement",
+			"Could not update entitlement "+entitlement.Id+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	entitlementToModelConverter(updated, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	tflog.Trace(ctx, "updated a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (e *EntitlementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state EntitlementResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultEntitlementTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := e.client.deleteEntitlement(ctx, state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting entitlement",
+			"Could not delete entitlement "+state.Id.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+func (e *EntitlementResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	entitlement, err := e.client.getEntitlement(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing entitlement",
+			fmt.Sprintf("Could not find entitlement %q: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	var data EntitlementResourceModel
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entitlementToModelConverter(entitlement, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func entitlementFromModelConverter(data EntitlementResourceModel) EntitlementDefinition {
+	return EntitlementDefinition{
+		Entitlement: Entitlement{
+			Provider: data.Provider.ValueString(),
+			Subject:  data.Subject.ValueString(),
+			Object:   data.Object.ValueString(),
+		},
+		Description: data.Description.ValueString(),
+		RiskLevel:   data.RiskLevel.ValueString(),
+		Integration: data.Integration.ValueString(),
+	}
+}
+
+func entitlementToModelConverter(entitlement *EntitlementDefinition, data *EntitlementResourceModel) {
+	data.Provider = types.StringValue(entitlement.Provider)
+	data.Subject = types.StringValue(entitlement.Subject)
+	data.Object = types.StringValue(entitlement.Object)
+	data.Description = optionalStringValue(entitlement.Description)
+	data.RiskLevel = preserveCase(data.RiskLevel, entitlement.RiskLevel)
+	data.Integration = optionalStringValue(entitlement.Integration)
+	data.Id = types.StringValue(entitlement.Id)
+}
+
+// optionalStringValue maps the empty strings the API returns for unset fields
+// back to null so they match an omitted attribute.
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,140 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccEntitlementResource(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_entitlement.%s", name)
+	policy_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntitlementDestroy,
+		Steps: []resource.TestStep{
+			// Create and Read testing, with a policy referencing the entitlement
+			{
+				Config: testAccEntitlementResourceConfig(name, "Read access", "MEDIUM"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "provider_name", "CROSSWIRE"),
+					resource.TestCheckResourceAttr(terraform_resource, "subject", "GROUP"),
+					resource.TestCheckResourceAttr(terraform_resource, "object", name),
+					resource.TestCheckResourceAttr(terraform_resource, "description", "Read access"),
+					resource.TestCheckResourceAttr(terraform_resource, "risk_level", "MEDIUM"),
+					resource.TestCheckResourceAttr(terraform_resource, "integration", "okta"),
+					resource.TestCheckResourceAttrSet(terraform_resource, "id"),
+					resource.TestCheckTypeSetElemNestedAttrs(policy_resource, "entitlements.*", map[string]string{
+						"provider": "CROSSWIRE", "subject": "GROUP", "object": name}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccEntitlementResourceConfig(name, "Read and write access", "HIGH"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "description", "Read and write access"),
+					resource.TestCheckResourceAttr(terraform_resource, "risk_level", "HIGH"),
+				),
+			},
+			// risk_level keeps its configured case, and timeouts only affect Terraform
+			{
+				Config: testAccEntitlementResourceConfigTimeouts(name, "Read and write access", "critical"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "risk_level", "critical"),
+					resource.TestCheckResourceAttr(terraform_resource, "timeouts.update", "2m"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCheckEntitlementDestroy(s *terraform.State) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "crosswire_entitlement" {
+			continue
+		}
+
+		_, err := client.getEntitlement(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("entitlement %s still exists", rs.Primary.ID)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	return testAccCheckPolicyDestroy(s)
+}
+
+func testAccEntitlementResourceConfig(name, description, riskLevel string) string {
+	return fmt.Sprintf(`
+resource "crosswire_entitlement" "%[1]s" {
+  provider_name = "CROSSWIRE"
+  subject       = "GROUP"
+  object        = "%[1]s"
+  description   = "%[2]s"
+  risk_level    = "%[3]s"
+  integration   = "okta"
+}
+
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = crosswire_entitlement.%[1]s.provider_name
+      subject  = crosswire_entitlement.%[1]s.subject
+      object   = crosswire_entitlement.%[1]s.object
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ADMIN"
+      }
+    ]
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+}
+`, name, description, riskLevel)
+}
+
+func testAccEntitlementResourceConfigTimeouts(name, description, riskLevel string) string {
+	return strings.Replace(testAccEntitlementResourceConfig(name, description, riskLevel), `  integration   = "okta"
+`, `  integration   = "okta"
+
+  timeouts {
+    create = "2m"
+    update = "2m"
+  }
+`, 1)
+}
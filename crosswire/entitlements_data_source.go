@@ -0,0 +1,166 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &EntitlementsDataSource{}
+var _ datasource.DataSourceWithConfigure = &EntitlementsDataSource{}
+
+func NewEntitlementsDataSource() datasource.DataSource {
+	return &EntitlementsDataSource{}
+}
+
+// EntitlementsDataSource defines the data source implementation.
+type EntitlementsDataSource struct {
+	client *Client
+}
+
+// EntitlementsDataSourceModel describes the data source data model.
+type EntitlementsDataSourceModel struct {
+	ProviderName  types.String              `tfsdk:"provider_name"`
+	SubjectPrefix types.String              `tfsdk:"subject_prefix"`
+	Entitlements  []CatalogEntitlementModel `tfsdk:"entitlements"`
+
+	Id types.String `tfsdk:"id"`
+}
+
+// CatalogEntitlementModel is one entry of the entitlement catalog. provider,
+// subject and object use the same names as EntitlementModel so entries can be
+// passed straight into a policy's entitlements.
+type CatalogEntitlementModel struct {
+	Id          types.String `tfsdk:"id"`
+	Provider    types.String `tfsdk:"provider"`
+	Subject     types.String `tfsdk:"subject"`
+	Object      types.String `tfsdk:"object"`
+	Description types.String `tfsdk:"description"`
+	RiskLevel   types.String `tfsdk:"risk_level"`
+	Integration types.String `tfsdk:"integration"`
+}
+
+func (d *EntitlementsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entitlements"
+}
+
+func (d *EntitlementsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Crosswire entitlement catalog, optionally filtered by provider and subject prefix.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return entitlements with this provider, e.g. GITHUB.",
+			},
+			"subject_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return entitlements whose subject starts with this prefix.",
+			},
+			"entitlements": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":          schema.StringAttribute{Computed: true},
+						"provider":    schema.StringAttribute{Computed: true},
+						"subject":     schema.StringAttribute{Computed: true},
+						"object":      schema.StringAttribute{Computed: true},
+						"description": schema.StringAttribute{Computed: true},
+						"risk_level":  schema.StringAttribute{Computed: true},
+						"integration": schema.StringAttribute{Computed: true},
+					},
+				},
+				Description: "Matching entitlements, sorted by provider, subject and object.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Placeholder identifier for this data source.",
+			},
+		},
+	}
+}
+
+func (d *EntitlementsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EntitlementsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EntitlementsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entitlements, err := d.client.listEntitlements(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Entitlements",
+			"Could not list entitlements, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(entitlements, func(i, j int) bool {
+		if entitlements[i].Provider != entitlements[j].Provider {
+			return entitlements[i].Provider < entitlements[j].Provider
+		}
+		if entitlements[i].Subject != entitlements[j].Subject {
+			return entitlements[i].Subject < entitlements[j].Subject
+		}
+		return entitlements[i].Object < entitlements[j].Object
+	})
+
+	data.Entitlements = []CatalogEntitlementModel{}
+	for _, entitlement := range entitlements {
+		if !data.matches(entitlement) {
+			continue
+		}
+		data.Entitlements = append(data.Entitlements, CatalogEntitlementModel{
+			Id:          types.StringValue(entitlement.Id),
+			Provider:    types.StringValue(entitlement.Provider),
+			Subject:     types.StringValue(entitlement.Subject),
+			Object:      types.StringValue(entitlement.Object),
+			Description: optionalStringValue(entitlement.Description),
+			RiskLevel:   types.StringValue(entitlement.RiskLevel),
+			Integration: optionalStringValue(entitlement.Integration),
+		})
+	}
+	data.Id = types.StringValue("crosswire_entitlements")
+
+	tflog.Trace(ctx, "read a data source", map[string]any{"entitlements": len(data.Entitlements), "total": len(entitlements)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches reports whether entitlement satisfies every filter set on the model.
+func (data EntitlementsDataSourceModel) matches(entitlement EntitlementDefinition) bool {
+	if !data.ProviderName.IsNull() && entitlement.Provider != data.ProviderName.ValueString() {
+		return false
+	}
+	if !data.SubjectPrefix.IsNull() && !strings.HasPrefix(entitlement.Subject, data.SubjectPrefix.ValueString()) {
+		return false
+	}
+	return true
+}
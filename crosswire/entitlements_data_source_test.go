@@ -0,0 +1,125 @@
+package crosswire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEntitlementsDataSource(t *testing.T) {
+	name := RandomStringGenerator(16)
+	data_source := fmt.Sprintf("data.crosswire_entitlements.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntitlementDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEntitlementsDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(data_source, "entitlements.#", "2"),
+					resource.TestCheckResourceAttr(data_source, "entitlements.0.object", name+"-b"),
+					resource.TestCheckResourceAttr(data_source, "entitlements.0.subject", name+"_TEAM_MAINTAINER"),
+					resource.TestCheckResourceAttr(data_source, "entitlements.0.description", "Maintainers"),
+					resource.TestCheckResourceAttr(data_source, "entitlements.1.object", name+"-a"),
+					resource.TestCheckResourceAttr(data_source, "entitlements.1.risk_level", "LOW"),
+					resource.TestCheckNoResourceAttr(data_source, "entitlements.1.description"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEntitlementsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "crosswire_entitlement" "%[1]s_a" {
+  provider_name = "GITHUB"
+  subject       = "%[1]s_TEAM_MEMBER"
+  object        = "%[1]s-a"
+}
+
+resource "crosswire_entitlement" "%[1]s_b" {
+  provider_name = "GITHUB"
+  subject       = "%[1]s_TEAM_MAINTAINER"
+  object        = "%[1]s-b"
+  description   = "Maintainers"
+}
+
+resource "crosswire_entitlement" "%[1]s_other" {
+  provider_name = "AWS"
+  subject       = "%[1]s_TEAM_MEMBER"
+  object        = "%[1]s-c"
+}
+
+data "crosswire_entitlements" "%[1]s" {
+  provider_name  = "GITHUB"
+  subject_prefix = "%[1]s_TEAM_"
+
+  depends_on = [
+    crosswire_entitlement.%[1]s_a,
+    crosswire_entitlement.%[1]s_b,
+    crosswire_entitlement.%[1]s_other,
+  ]
+}
+`, name)
+}
+
+func TestEntitlementsDataSourceModelMatches(t *testing.T) {
+	entitlement := EntitlementDefinition{
+		Entitlement: Entitlement{Provider: "GITHUB", Subject: "TEAM_MEMBER", Object: "platform"},
+	}
+
+	tests := []struct {
+		name  string
+		model EntitlementsDataSourceModel
+		want  bool
+	}{
+		{
+			name: "no filters",
+			want: true,
+		},
+		{
+			name:  "provider",
+			model: EntitlementsDataSourceModel{ProviderName: types.StringValue("GITHUB")},
+			want:  true,
+		},
+		{
+			name:  "other provider",
+			model: EntitlementsDataSourceModel{ProviderName: types.StringValue("AWS")},
+			want:  false,
+		},
+		{
+			name:  "subject prefix",
+			model: EntitlementsDataSourceModel{SubjectPrefix: types.StringValue("TEAM_")},
+			want:  true,
+		},
+		{
+			name:  "full subject",
+			model: EntitlementsDataSourceModel{SubjectPrefix: types.StringValue("TEAM_MEMBER")},
+			want:  true,
+		},
+		{
+			name:  "other subject prefix",
+			model: EntitlementsDataSourceModel{SubjectPrefix: types.StringValue("REPO_")},
+			want:  false,
+		},
+		{
+			name: "both filters must match",
+			model: EntitlementsDataSourceModel{
+				ProviderName:  types.StringValue("AWS"),
+				SubjectPrefix: types.StringValue("TEAM_"),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.matches(entitlement); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// ExportPoliciesFile holds the exported crosswire_policy resources.
+	ExportPoliciesFile = "policies.tf"
+	// ExportImportsFile holds the import blocks adopting exported policies.
+	ExportImportsFile = "imports.tf"
+)
+
+// ExportPolicies writes every policy in the organization to dir as
+// crosswire_policy resources, plus import blocks (Terraform 1.5+) so a
+// subsequent apply adopts the existing policies instead of recreating them.
+// Existing files are never overwritten.
+func ExportPolicies(ctx context.Context, client *Client, dir string) error {
+	policies, err := client.listPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("listing policies: %w", err)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Name != policies[j].Name {
+			return policies[i].Name < policies[j].Name
+		}
+		return policies[i].Id < policies[j].Id
+	})
+
+	resources := hclwrite.NewEmptyFile()
+	imports := hclwrite.NewEmptyFile()
+	labels := map[string]bool{}
+	for i, policy := range policies {
+		label := uniqueResourceLabel(policy.Name, labels)
+
+		if i > 0 {
+			resources.Body().AppendNewline()
+			imports.Body().AppendNewline()
+		}
+		writePolicyResource(resources.Body(), label, policy)
+
+		importBlock := imports.Body().AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: "crosswire_policy"},
+			hcl.TraverseAttr{Name: label},
+		})
+		importBlock.SetAttributeValue("id", cty.StringVal(policy.Id))
+	}
+
+	return writeNewFiles(
+		exportFile{filepath.Join(dir, ExportPoliciesFile), resources.Bytes()},
+		exportFile{filepath.Join(dir, ExportImportsFile), imports.Bytes()},
+	)
+}
+
+type exportFile struct {
+	name     string
+	contents []byte
+}
+
+// writeNewFiles creates every file before writing any of them, so that one
+// already existing leaves none of the others behind. Created files are
+// removed again when writing fails.
+func writeNewFiles(files ...exportFile) error {
+	var created []*os.File
+	fail := func(err error) error {
+		for _, file := range created {
+			file.Close()
+			os.Remove(file.Name())
+		}
+		return err
+	}
+
+	for _, exported := range files {
+		file, err := os.OpenFile(exported.name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return fail(err)
+		}
+		created = append(created, file)
+	}
+	for i, file := range created {
+		if _, err := file.Write(hclwrite.Format(files[i].contents)); err != nil {
+			return fail(err)
+		}
+	}
+	for _, file := range created {
+		if err := file.Close(); err != nil {
+			return fail(err)
+		}
+	}
+	return nil
+}
+
+func writePolicyResource(body *hclwrite.Body, label string, policy Policy) {
+	resource := body.AppendNewBlock("resource", []string{"crosswire_policy", label}).Body()
+
+	resource.SetAttributeRaw("owner", userTokens(policy.Owner))
+	resource.SetAttributeValue("name", cty.StringVal(policy.Name))
+	resource.SetAttributeRaw("entitlements", entitlementsTokens(policy.Entitlements))
+	if conditionDepth(policy.Condition) <= maxConditionDepth {
+		resource.SetAttributeRaw("condition", conditionTokens(policy.Condition))
+	} else {
+		// The condition attribute cannot nest this deep, so encode the same
+		// object expression as condition_json.
+		resource.SetAttributeRaw("condition_json", hclwrite.TokensForFunctionCall("jsonencode", conditionTokens(policy.Condition)))
+	}
+
+	// Omit values matching the schema defaults to keep the output minimal.
+	if policy.SpecialApprover != nil && !strings.EqualFold(*policy.SpecialApprover, "NONE") {
+		resource.SetAttributeValue("special_approver", cty.StringVal(*policy.SpecialApprover))
+	}
+	if policy.ApprovalBehavior != nil && !strings.EqualFold(*policy.ApprovalBehavior, "ANY") {
+		resource.SetAttributeValue("approval_behavior", cty.StringVal(*policy.ApprovalBehavior))
+	}
+	if policy.MinApprovals != nil && *policy.MinApprovals > 0 {
+		resource.SetAttributeValue("min_approvals", cty.NumberIntVal(*policy.MinApprovals))
+	}
+	if len(policy.UserApprovers) > 0 {
+		resource.SetAttributeRaw("user_approvers", usersTokens(policy.UserApprovers))
+	}
+	if len(policy.EntitlementApprovers) > 0 {
+		resource.SetAttributeRaw("entitlement_approvers", entitlementsTokens(policy.EntitlementApprovers))
+	}
+	if len(policy.GroupApprovers) > 0 {
+		resource.SetAttributeRaw("group_approvers", stringsTokens(policy.GroupApprovers))
+	}
+	if len(policy.ApprovalStages) > 0 {
+		var stages []hclwrite.Tokens
+		for _, stage := range policy.ApprovalStages {
+			stages = append(stages, approvalStageTokens(stage))
+		}
+		resource.SetAttributeRaw("approval_stages", listTokens(stages))
+	}
+	if policy.RequireJustification {
+		resource.SetAttributeValue("require_justification", cty.True)
+	}
+	if policy.JustificationMinLength != nil && *policy.JustificationMinLength > 0 {
+		resource.SetAttributeValue("justification_min_length", cty.NumberIntVal(*policy.JustificationMinLength))
+	}
+	if policy.TicketPattern != "" {
+		resource.SetAttributeValue("ticket_pattern", cty.StringVal(policy.TicketPattern))
+	}
+	if policy.TicketSystem != "" {
+		resource.SetAttributeValue("ticket_system", cty.StringVal(policy.TicketSystem))
+	}
+	if policy.DefaultTtl != nil && *policy.DefaultTtl > 0 {
+		resource.SetAttributeValue("default_ttl", cty.StringVal(formatDuration(*policy.DefaultTtl)))
+	}
+	if policy.Ttl != nil && *policy.Ttl > 0 {
+		resource.SetAttributeValue("ttl", cty.NumberIntVal(*policy.Ttl))
+	}
+	if policy.AllowExtension {
+		resource.SetAttributeValue("allow_extension", cty.True)
+	}
+	if policy.MaxExtensions != nil && *policy.MaxExtensions > 0 {
+		resource.SetAttributeValue("max_extensions", cty.NumberIntVal(*policy.MaxExtensions))
+	}
+	if len(policy.AccessWindows) > 0 {
+		var windows []hclwrite.Tokens
+		for _, window := range policy.AccessWindows {
+			windows = append(windows, accessWindowTokens(window))
+		}
+		resource.SetAttributeRaw("access_windows", listTokens(windows))
+	}
+}
+
+func userTokens(email string) hclwrite.Tokens {
+	return objectTokens(
+		objectAttribute{"email_address", hclwrite.TokensForValue(cty.StringVal(email))},
+	)
+}
+
+// usersTokens lists emails as user objects, sorted.
+func usersTokens(emails []string) hclwrite.Tokens {
+	sorted := append([]string(nil), emails...)
+	sort.Strings(sorted)
+	var users []hclwrite.Tokens
+	for _, email := range sorted {
+		users = append(users, userTokens(email))
+	}
+	return listTokens(users)
+}
+
+func stringsTokens(values []string) hclwrite.Tokens {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	var items []cty.Value
+	for _, value := range sorted {
+		items = append(items, cty.StringVal(value))
+	}
+	return hclwrite.TokensForValue(cty.ListVal(items))
+}
+
+func approvalStageTokens(stage ApprovalStage) hclwrite.Tokens {
+	var attributes []objectAttribute
+	if stage.SpecialApprover != nil && !strings.EqualFold(*stage.SpecialApprover, "NONE") {
+		attributes = append(attributes, objectAttribute{"special_approver", hclwrite.TokensForValue(cty.StringVal(*stage.SpecialApprover))})
+	}
+	if stage.ApprovalBehavior != nil && !strings.EqualFold(*stage.ApprovalBehavior, "ANY") {
+		attributes = append(attributes, objectAttribute{"approval_behavior", hclwrite.TokensForValue(cty.StringVal(*stage.ApprovalBehavior))})
+	}
+	if stage.MinApprovals != nil && *stage.MinApprovals > 0 {
+		attributes = append(attributes, objectAttribute{"min_approvals", hclwrite.TokensForValue(cty.NumberIntVal(*stage.MinApprovals))})
+	}
+	if len(stage.UserApprovers) > 0 {
+		attributes = append(attributes, objectAttribute{"user_approvers", usersTokens(stage.UserApprovers)})
+	}
+	if len(stage.EntitlementApprovers) > 0 {
+		attributes = append(attributes, objectAttribute{"entitlement_approvers", entitlementsTokens(stage.EntitlementApprovers)})
+	}
+	if len(stage.GroupApprovers) > 0 {
+		attributes = append(attributes, objectAttribute{"group_approvers", stringsTokens(stage.GroupApprovers)})
+	}
+	return objectTokens(attributes...)
+}
+
+// accessWindowTokens writes window with its days in the order of the week.
+func accessWindowTokens(window AccessWindow) hclwrite.Tokens {
+	var days []cty.Value
+	for _, day := range accessWindowDays {
+		for _, configured := range window.Days {
+			if strings.EqualFold(configured, day) {
+				days = append(days, cty.StringVal(configured))
+				break
+			}
+		}
+	}
+	daysValue := cty.ListValEmpty(cty.String)
+	if len(days) > 0 {
+		daysValue = cty.ListVal(days)
+	}
+	return objectTokens(
+		objectAttribute{"days", hclwrite.TokensForValue(daysValue)},
+		objectAttribute{"start_time", hclwrite.TokensForValue(cty.StringVal(window.StartTime))},
+		objectAttribute{"end_time", hclwrite.TokensForValue(cty.StringVal(window.EndTime))},
+		objectAttribute{"time_zone", hclwrite.TokensForValue(cty.StringVal(window.TimeZone))},
+	)
+}
+
+func entitlementsTokens(entitlements []Entitlement) hclwrite.Tokens {
+	var items []hclwrite.Tokens
+	for _, entitlement := range sortEntitlements(entitlements) {
+		items = append(items, objectTokens(
+			objectAttribute{"provider", hclwrite.TokensForValue(cty.StringVal(entitlement.Provider))},
+			objectAttribute{"subject", hclwrite.TokensForValue(cty.StringVal(entitlement.Subject))},
+			objectAttribute{"object", hclwrite.TokensForValue(cty.StringVal(entitlement.Object))},
+		))
+	}
+	return listTokens(items)
+}
+
+func conditionTokens(condition Condition) hclwrite.Tokens {
+	attributes := []objectAttribute{
+		{"quantifier", hclwrite.TokensForValue(cty.StringVal(condition.Quantifier))},
+	}
+	if condition.Threshold != nil {
+		attributes = append(attributes, objectAttribute{"threshold", hclwrite.TokensForValue(cty.NumberIntVal(*condition.Threshold))})
+	}
+	if len(condition.Entitlements) > 0 {
+		attributes = append(attributes, objectAttribute{"entitlements", entitlementsTokens(condition.Entitlements)})
+	}
+	if len(condition.Subconditions) > 0 {
+		var subconditions []hclwrite.Tokens
+		for _, subcondition := range condition.Subconditions {
+			subconditions = append(subconditions, conditionTokens(subcondition))
+		}
+		attributes = append(attributes, objectAttribute{"subconditions", listTokens(subconditions)})
+	}
+	return objectTokens(attributes...)
+}
+
+// objectAttribute is one key of an object expression. hclwrite sorts the keys
+// of cty objects, so objects are built from tokens to keep the
+// provider/subject/object order used throughout the docs.
+type objectAttribute struct {
+	name   string
+	tokens hclwrite.Tokens
+}
+
+func objectTokens(attributes ...objectAttribute) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")},
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+	}
+	for _, attribute := range attributes {
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(attribute.name)})
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")})
+		tokens = append(tokens, attribute.tokens...)
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+}
+
+func listTokens(items []hclwrite.Tokens) hclwrite.Tokens {
+	if len(items) == 0 {
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")},
+			{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")},
+		}
+	}
+
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")},
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+	}
+	for i, item := range items {
+		tokens = append(tokens, item...)
+		if i < len(items)-1 {
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+		}
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+}
+
+// uniqueResourceLabel turns a policy name into a valid Terraform resource name
+// that has not been handed out yet.
+func uniqueResourceLabel(name string, used map[string]bool) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	label := strings.Trim(b.String(), "_-")
+	if label == "" {
+		label = "policy"
+	}
+	if !unicode.IsLetter(rune(label[0])) && label[0] != '_' {
+		label = "policy_" + label
+	}
+
+	candidate := label
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[candidate] = true
+	return candidate
+}
@@ -0,0 +1,176 @@
+package crosswire
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func TestExportPolicies(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newFakeServer()
+	defer server.Close()
+
+	token := fakeAPIToken
+	client, err := NewClient(ctx, &server.URL, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies := []Policy{
+		{
+			Owner:        "user@company.com",
+			Name:         "Prod DB Admin",
+			Entitlements: []Entitlement{{Provider: "AWS", Subject: "ROLE", Object: "db-admin"}},
+			Condition: Condition{
+				Quantifier:   "ANY",
+				Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "dba"}},
+				Subconditions: []Condition{{
+					Quantifier:   "ALL",
+					Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "oncall"}},
+				}},
+			},
+			ApprovalBehavior:     ToPointer("ALL"),
+			RequireJustification: true,
+			TicketPattern:        `^CHG-\d+$`,
+			UserApprovers:        []string{"b@company.com", "a@company.com"},
+			GroupApprovers:       []string{"group-2", "group-1"},
+			Ttl:                  ToPointer(int64(3600)),
+			DefaultTtl:           ToPointer(int64(1800)),
+			AllowExtension:       true,
+			MaxExtensions:        ToPointer(int64(2)),
+			AccessWindows: []AccessWindow{
+				{Days: []string{"FRIDAY", "MONDAY"}, StartTime: "09:00", EndTime: "17:30", TimeZone: "Europe/London"},
+			},
+		},
+		{
+			Owner:           "user@company.com",
+			Name:            "Prod DB Admin",
+			Entitlements:    []Entitlement{{Provider: "AWS", Subject: "ROLE", Object: "db-read"}},
+			Condition:       Condition{Quantifier: "ANY"},
+			SpecialApprover: ToPointer("MANAGER"),
+		},
+		{
+			Owner:        "user@company.com",
+			Name:         "Break Glass",
+			Entitlements: []Entitlement{{Provider: "AWS", Subject: "ROLE", Object: "admin"}},
+			Condition: Condition{Quantifier: "ALL", Subconditions: []Condition{{
+				Quantifier: "ANY", Subconditions: []Condition{{
+					Quantifier: "ALL", Subconditions: []Condition{{
+						Quantifier:   "AT_LEAST",
+						Threshold:    ToPointer(int64(1)),
+						Entitlements: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "sre"}},
+					}},
+				}},
+			}}},
+			ApprovalStages: []ApprovalStage{
+				{SpecialApprover: ToPointer("MANAGER")},
+				{UserApprovers: []string{"security@company.com"}, EntitlementApprovers: []Entitlement{{Provider: "OKTA", Subject: "GROUP", Object: "security"}}},
+			},
+		},
+	}
+	for _, policy := range policies {
+		if _, err := client.createPolicy(ctx, policy); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := ExportPolicies(ctx, client, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := hclparse.NewParser()
+	contents := map[string]string{}
+	for _, name := range []string{ExportPoliciesFile, ExportImportsFile} {
+		path := filepath.Join(dir, name)
+		if _, diags := parser.ParseHCLFile(path); diags.HasErrors() {
+			t.Fatalf("%s is not valid HCL: %s", name, diags.Error())
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[name] = string(raw)
+	}
+
+	for _, want := range []string{
+		`resource "crosswire_policy" "prod_db_admin" {`,
+		`resource "crosswire_policy" "prod_db_admin_2" {`,
+		`approval_behavior = "ALL"`,
+		`special_approver = "MANAGER"`,
+		`default_ttl           = "30m"`,
+		`ttl                   = 3600`,
+		`allow_extension       = true`,
+		`max_extensions        = 2`,
+		`days       = ["MONDAY", "FRIDAY"]`,
+		`time_zone  = "Europe/London"`,
+		`group_approvers       = ["group-1", "group-2"]`,
+		`quantifier = "ALL"`,
+		`condition_json = jsonencode({`,
+		`threshold  = 1`,
+		`approval_stages = [`,
+		`require_justification = true`,
+		`ticket_pattern        = "^CHG-\\d+$"`,
+		`email_address = "security@company.com"`,
+	} {
+		if !strings.Contains(contents[ExportPoliciesFile], want) {
+			t.Errorf("%s is missing %q:\n%s", ExportPoliciesFile, want, contents[ExportPoliciesFile])
+		}
+	}
+	if strings.Index(contents[ExportPoliciesFile], "a@company.com") > strings.Index(contents[ExportPoliciesFile], "b@company.com") {
+		t.Errorf("user approvers are not sorted:\n%s", contents[ExportPoliciesFile])
+	}
+
+	for _, want := range []string{
+		"to = crosswire_policy.prod_db_admin\n",
+		"to = crosswire_policy.prod_db_admin_2\n",
+		`id = "policy-1"`,
+		`id = "policy-2"`,
+	} {
+		if !strings.Contains(contents[ExportImportsFile], want) {
+			t.Errorf("%s is missing %q:\n%s", ExportImportsFile, want, contents[ExportImportsFile])
+		}
+	}
+
+	if err := ExportPolicies(ctx, client, dir); err == nil {
+		t.Error("expected exporting into a directory with existing files to fail")
+	}
+
+	// An existing imports.tf must not leave a new policies.tf behind.
+	dir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ExportImportsFile), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExportPolicies(ctx, client, dir); err == nil {
+		t.Errorf("expected exporting with an existing %s to fail", ExportImportsFile)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ExportPoliciesFile)); !os.IsNotExist(err) {
+		t.Errorf("expected no %s to be written, got %v", ExportPoliciesFile, err)
+	}
+}
+
+func TestUniqueResourceLabel(t *testing.T) {
+	used := map[string]bool{}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Prod DB Admin", want: "prod_db_admin"},
+		{name: "prod db admin", want: "prod_db_admin_2"},
+		{name: "2FA bypass", want: "policy_2fa_bypass"},
+		{name: "Café ☕", want: "caf"},
+		{name: "!!!", want: "policy"},
+		{name: "team-access", want: "team-access"},
+	}
+
+	for _, tt := range tests {
+		if got := uniqueResourceLabel(tt.name, used); got != tt.want {
+			t.Errorf("uniqueResourceLabel(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package crosswire
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeAPIToken is the only token accepted by the fake Crosswire API.
+const fakeAPIToken = "fake-crosswire-token"
+
+// fakeServer is an in-memory stand-in for the Crosswire API so acceptance
+// tests can run without a live tenant. Set CROSSWIRE_FAKE_API=1 to use it.
+type fakeServer struct {
+	mu           sync.Mutex
+	policies     map[string]Policy
+	entitlements map[string]EntitlementDefinition
+	groups       map[string]Group
+	users        []User
+	nextId       int
+
+	// requests lists the method and URI of every request received, so tests
+	// can check what the provider sent.
+	requests []string
+}
+
+// testAccFakeServer is the fake API acceptance tests run against, or nil when
+// they run against a live tenant.
+var testAccFakeServer *fakeServer
+
+// fakeUsers is the fake organization's directory used by acceptance tests.
+var fakeUsers = []User{
+	{Id: "user-1", EmailAddress: "user@company.com", Name: "Test User", Manager: "approver@company.com", Department: "Engineering", Active: true},
+	{Id: "user-2", EmailAddress: "approver@company.com", Name: "Approver", Department: "Security", Active: true},
+	{Id: "user-3", EmailAddress: "second.approver@company.com", Name: "Second Approver", Manager: "approver@company.com", Department: "Security", Active: true},
+	{Id: "user-4", EmailAddress: "former.employee@company.com", Name: "Former Employee", Manager: "approver@company.com", Department: "Security", Active: false},
+}
+
+func newFakeServer() (*httptest.Server, *fakeServer) {
+	fake := &fakeServer{
+		policies:     map[string]Policy{},
+		entitlements: map[string]EntitlementDefinition{},
+		groups:       map[string]Group{},
+		users:        fakeUsers,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/integrations/crosswire_terraform/validate", fake.handleValidate)
+	mux.HandleFunc("/integrations/crosswire_terraform/policy", fake.handlePolicy)
+	mux.HandleFunc("/integrations/crosswire_terraform/policies", fake.handlePolicies)
+	mux.HandleFunc("/integrations/crosswire_terraform/entitlement", fake.handleEntitlement)
+	mux.HandleFunc("/integrations/crosswire_terraform/entitlements", fake.handleEntitlements)
+	mux.HandleFunc("/integrations/crosswire_terraform/group", fake.handleGroup)
+	mux.HandleFunc("/integrations/crosswire_terraform/user", fake.handleUser)
+	mux.HandleFunc("/integrations/crosswire_terraform/users", fake.handleUsers)
+	mux.HandleFunc("/integrations/crosswire_terraform/users/lookup", fake.handleUserLookup)
+
+	return httptest.NewServer(fake.authenticate(mux)), fake
+}
+
+func (f *fakeServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Token") != fakeAPIToken {
+			writeFakeError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		w.Header().Set("X-Request-Id", fmt.Sprintf("fake-%s-%s", r.Method, r.URL.Path))
+		f.mu.Lock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+		f.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f *fakeServer) handleValidate(w http.ResponseWriter, r *http.Request) {
+	writeFakeJSON(w, map[string]any{"success": true})
+}
+
+func (f *fakeServer) handlePolicy(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		label := r.URL.Query().Get("label")
+		found := map[string]Policy{}
+		for id, policy := range f.policies {
+			if id == label || policy.Name == label {
+				found[id] = policy
+			}
+		}
+		writeFakeJSON(w, map[string]any{"policies": found})
+
+	case http.MethodPost:
+		var policy Policy
+		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		f.nextId++
+		policy.Id = fmt.Sprintf("policy-%d", f.nextId)
+		policy.State = "ACTIVE"
+		f.policies[policy.Id] = normalizeFakePolicy(policy)
+		writeFakeJSON(w, f.policies[policy.Id])
+
+	case http.MethodPut:
+		id := r.URL.Query().Get("id")
+		existing, ok := f.policies[id]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "policy not found")
+			return
+		}
+		var policy Policy
+		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		policy.Id = id
+		policy.State = existing.State
+		f.policies[id] = normalizeFakePolicy(policy)
+		writeFakeJSON(w, f.policies[id])
+
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		policy, ok := f.policies[id]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "policy not found")
+			return
+		}
+		switch r.URL.Query().Get("revocationBehavior") {
+		case "REVOKE", "EXPIRE":
+			delete(f.policies, id)
+		case "ARCHIVE":
+			policy.State = "ARCHIVED"
+			f.policies[id] = policy
+		default:
+			writeFakeError(w, http.StatusBadRequest, "unknown revocationBehavior")
+			return
+		}
+		writeFakeJSON(w, map[string]any{"success": true})
+
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func (f *fakeServer) handlePolicies(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]string, 0, len(f.policies))
+	for id := range f.policies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	start, end, next, err := fakePage(r, len(ids))
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	policies := []Policy{}
+	for _, id := range ids[start:end] {
+		policies = append(policies, f.policies[id])
+	}
+	writeFakeJSON(w, map[string]any{"policies": policies, "nextPageToken": next})
+}
+
+func (f *fakeServer) handleEntitlement(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := r.URL.Query().Get("id")
+	existing, exists := f.entitlements[id]
+	if r.Method != http.MethodPost && !exists {
+		writeFakeError(w, http.StatusNotFound, "entitlement not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, existing)
+
+	case http.MethodPost, http.MethodPut:
+		var entitlement EntitlementDefinition
+		if err := json.NewDecoder(r.Body).Decode(&entitlement); err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if r.Method == http.MethodPost {
+			for _, other := range f.entitlements {
+				if other.Entitlement == entitlement.Entitlement {
+					writeFakeError(w, http.StatusConflict, "entitlement already exists")
+					return
+				}
+			}
+			f.nextId++
+			id = fmt.Sprintf("entitlement-%d", f.nextId)
+		} else {
+			// The tuple identifies the entitlement and cannot change.
+			entitlement.Entitlement = existing.Entitlement
+		}
+		entitlement.Id = id
+		entitlement.RiskLevel = strings.ToUpper(entitlement.RiskLevel)
+		if entitlement.RiskLevel == "" {
+			entitlement.RiskLevel = "LOW"
+		}
+		f.entitlements[id] = entitlement
+		writeFakeJSON(w, entitlement)
+
+	case http.MethodDelete:
+		delete(f.entitlements, id)
+		writeFakeJSON(w, map[string]any{"success": true})
+
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func (f *fakeServer) handleEntitlements(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]string, 0, len(f.entitlements))
+	for id := range f.entitlements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	start, end, next, err := fakePage(r, len(ids))
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entitlements := []EntitlementDefinition{}
+	for _, id := range ids[start:end] {
+		entitlements = append(entitlements, f.entitlements[id])
+	}
+	writeFakeJSON(w, map[string]any{"entitlements": entitlements, "nextPageToken": next})
+}
+
+func (f *fakeServer) handleGroup(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := r.URL.Query().Get("id")
+	if _, exists := f.groups[id]; r.Method != http.MethodPost && !exists {
+		writeFakeError(w, http.StatusNotFound, "group not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, f.groups[id])
+
+	case http.MethodPost, http.MethodPut:
+		var group Group
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if r.Method == http.MethodPost {
+			f.nextId++
+			id = fmt.Sprintf("group-%d", f.nextId)
+		}
+		for _, nested := range group.Groups {
+			if _, ok := f.groups[nested]; !ok || nested == id {
+				writeFakeError(w, http.StatusBadRequest, "invalid nested group "+nested)
+				return
+			}
+		}
+		group.Id = id
+		f.groups[id] = group
+		writeFakeJSON(w, group)
+
+	case http.MethodDelete:
+		delete(f.groups, id)
+		writeFakeJSON(w, map[string]any{"success": true})
+
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func (f *fakeServer) handleUser(w http.ResponseWriter, r *http.Request) {
+	for _, user := range f.users {
+		if user.Id == r.URL.Query().Get("id") {
+			writeFakeJSON(w, user)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "user not found")
+}
+
+func (f *fakeServer) handleUsers(w http.ResponseWriter, r *http.Request) {
+	start, end, next, err := fakePage(r, len(f.users))
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeFakeJSON(w, map[string]any{"users": f.users[start:end], "nextPageToken": next})
+}
+
+func (f *fakeServer) handleUserLookup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeFakeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		return
+	}
+
+	var lookup userLookupRequest
+	if err := json.NewDecoder(r.Body).Decode(&lookup); err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users := []User{}
+	for _, email := range lookup.EmailAddresses {
+		for _, user := range f.users {
+			if strings.EqualFold(user.EmailAddress, email) {
+				users = append(users, user)
+			}
+		}
+	}
+	writeFakeJSON(w, map[string]any{"users": users})
+}
+
+// fakePage resolves the pageSize and pageToken query parameters against a
+// collection of total items. Page tokens are plain offsets.
+func fakePage(r *http.Request, total int) (start, end int, next string, err error) {
+	pageSize := total
+	if raw := r.URL.Query().Get("pageSize"); raw != "" {
+		if pageSize, err = strconv.Atoi(raw); err != nil || pageSize < 1 {
+			return 0, 0, "", fmt.Errorf("invalid pageSize %q", raw)
+		}
+	}
+	if raw := r.URL.Query().Get("pageToken"); raw != "" {
+		if start, err = strconv.Atoi(raw); err != nil || start < 0 || start > total {
+			return 0, 0, "", fmt.Errorf("invalid pageToken %q", raw)
+		}
+	}
+
+	end = start + pageSize
+	if end >= total {
+		return start, total, "", nil
+	}
+	return start, end, strconv.Itoa(end), nil
+}
+
+// normalizeFakePolicy mimics the API's handling of enum fields and unset
+// approver settings.
+func normalizeFakePolicy(policy Policy) Policy {
+	if policy.SpecialApprover == nil || *policy.SpecialApprover == "" {
+		policy.SpecialApprover = ToPointer("NONE")
+	}
+	if policy.ApprovalBehavior == nil || *policy.ApprovalBehavior == "" {
+		policy.ApprovalBehavior = ToPointer("ANY")
+	}
+	policy.SpecialApprover = ToPointer(strings.ToUpper(*policy.SpecialApprover))
+	policy.ApprovalBehavior = ToPointer(strings.ToUpper(*policy.ApprovalBehavior))
+	policy.Condition = normalizeFakeCondition(policy.Condition)
+	return policy
+}
+
+// normalizeFakeCondition upper cases the quantifier at every level.
+func normalizeFakeCondition(condition Condition) Condition {
+	condition.Quantifier = strings.ToUpper(condition.Quantifier)
+	subconditions := condition.Subconditions
+	condition.Subconditions = nil
+	for _, subcondition := range subconditions {
+		condition.Subconditions = append(condition.Subconditions, normalizeFakeCondition(subcondition))
+	}
+	return condition
+}
+
+// testAccCheckFakeRequest checks whether the fake API received a request
+// starting with prefix and containing query, e.g. "DELETE /path?" and
+// "revocationBehavior=EXPIRE", since the previous check sharing mark. It
+// passes against a live tenant.
+func testAccCheckFakeRequest(mark *int, prefix, query string, want bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccFakeServer == nil {
+			return nil
+		}
+		testAccFakeServer.mu.Lock()
+		defer testAccFakeServer.mu.Unlock()
+
+		requests := testAccFakeServer.requests[*mark:]
+		*mark = len(testAccFakeServer.requests)
+		for _, request := range requests {
+			if strings.HasPrefix(request, prefix) && strings.Contains(request, query) {
+				if !want {
+					return fmt.Errorf("unexpected request %s", request)
+				}
+				return nil
+			}
+		}
+		if want {
+			return fmt.Errorf("no request matching %q and %q, got %q", prefix, query, requests)
+		}
+		return nil
+	}
+}
+
+func writeFakeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"message": message})
+}
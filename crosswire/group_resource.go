@@ -0,0 +1,427 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &GroupResource{}
+var _ resource.ResourceWithConfigure = &GroupResource{}
+var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
+
+// defaultGroupTimeout applies to each operation when no timeouts block is set.
+const defaultGroupTimeout = 5 * time.Minute
+
+func NewGroupResource() resource.Resource {
+	return &GroupResource{}
+}
+
+// GroupResource defines the resource implementation.
+type GroupResource struct {
+	client *Client
+}
+
+// GroupResourceModel describes the resource data model.
+type GroupResourceModel struct {
+	Name         types.String   `tfsdk:"name"`
+	Description  types.String   `tfsdk:"description"`
+	Members      []UserModel    `tfsdk:"members"`
+	NestedGroups []types.String `tfsdk:"nested_groups"`
+	Owners       []UserModel    `tfsdk:"owners"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+func (g *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (g *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A group of Crosswire users managed in Terraform. Reference its `id` in a policy's `group_approvers` to have the group approve requests.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the group",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Human readable description of the group's purpose.",
+			},
+			"members": schema.SetNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributesV0(),
+				},
+				Description: "Set of users (email addresses) belonging to the group.",
+			},
+			"nested_groups": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Set of crosswire_group ids whose members also belong to this group. A group cannot nest itself, and with the provider's strict_validation neither a group that already contains it at any depth.",
+			},
+			"owners": schema.SetNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributesV0(),
+				},
+				Description: "Set of users (email addresses) allowed to manage the group in Crosswire.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Crosswire group id",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp Terraform received the group's latest update",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan rejects nested_groups that would nest a group in itself, and
+// checks members and owners against Crosswire users unless the provider has
+// validate_users disabled.
+func (g *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying or before the provider is configured.
+	if req.Plan.Raw.IsNull() || g.client == nil {
+		return
+	}
+
+	// A group being created has no id yet, so nothing can reference it.
+	if !req.State.Raw.IsNull() {
+		var id types.String
+		var nested types.Set
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("nested_groups"), &nested)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		g.client.checkNestedGroups(ctx, id.ValueString(), nested, &resp.Diagnostics)
+	}
+
+	if !g.client.ValidateUsers {
+		return
+	}
+
+	var references []userReference
+	for _, name := range []string{"members", "owners"} {
+		var users types.Set
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &users)...)
+		references = append(references, userSetReferences(users, path.Root(name))...)
+	}
+	g.client.checkUsers(ctx, references, &resp.Diagnostics)
+}
+
+func (g *GroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	g.client = client
+}
+
+func (g *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := g.client.createGroup(ctx, groupFromModelConverter(data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating group",
+			"Could not create group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	groupToModelConverter(created, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (g *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	group, err := g.client.getGroup(ctx, state.Id.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Group no longer exists in Crosswire, removing from state", map[string]any{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group",
+			"Could not read group "+state.Id.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	groupToModelConverter(group, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (g *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	group := groupFromModelConverter(data)
+	group.Id = id.ValueString()
+
+	updated, err := g.client.updateGroup(ctx, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating group",
+			"Could not update group "+group.Id+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	groupToModelConverter(updated, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	tflog.Trace(ctx, "updated a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (g *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultGroupTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := g.client.deleteGroup(ctx, state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting group",
+			"Could not delete group "+state.Id.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+func (g *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	group, err := g.client.getGroup(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing group",
+			fmt.Sprintf("Could not find group %q: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	var data GroupResourceModel
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupToModelConverter(group, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func groupFromModelConverter(data GroupResourceModel) Group {
+	group := Group{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	}
+	for _, member := range data.Members {
+		group.Members = append(group.Members, member.EmailAddress.ValueString())
+	}
+	for _, nested := range data.NestedGroups {
+		group.Groups = append(group.Groups, nested.ValueString())
+	}
+	for _, owner := range data.Owners {
+		group.Owners = append(group.Owners, owner.EmailAddress.ValueString())
+	}
+	return group
+}
+
+func groupToModelConverter(group *Group, data *GroupResourceModel) {
+	data.Name = types.StringValue(group.Name)
+	data.Description = optionalStringValue(group.Description)
+	data.Members = nil
+	for _, member := range group.Members {
+		data.Members = append(data.Members, UserModel{EmailAddress: types.StringValue(member)})
+	}
+	data.NestedGroups = nil
+	for _, nested := range group.Groups {
+		data.NestedGroups = append(data.NestedGroups, types.StringValue(nested))
+	}
+	data.Owners = nil
+	for _, owner := range group.Owners {
+		data.Owners = append(data.Owners, UserModel{EmailAddress: types.StringValue(owner)})
+	}
+	data.Id = types.StringValue(group.Id)
+}
+
+// checkNestedGroups adds an error when nested lists the group with the given
+// id itself and, when the provider has strict_validation enabled, when a
+// nested group already contains that group at any depth.
+func (c *Client) checkNestedGroups(ctx context.Context, id string, nested types.Set, diags *diag.Diagnostics) {
+	if id == "" || nested.IsNull() || nested.IsUnknown() {
+		return
+	}
+
+	var roots []string
+	for _, element := range nested.Elements() {
+		nestedId, ok := element.(types.String)
+		if !ok || nestedId.IsNull() || nestedId.IsUnknown() {
+			continue
+		}
+		if nestedId.ValueString() == id {
+			diags.AddAttributeError(
+				path.Root("nested_groups"),
+				"Invalid Nested Group",
+				fmt.Sprintf("Group %q cannot be nested in itself.", id),
+			)
+			continue
+		}
+		roots = append(roots, nestedId.ValueString())
+	}
+	if !c.StrictValidation {
+		return
+	}
+
+	groups := map[string]*Group{}
+	for _, root := range roots {
+		visited := map[string]bool{root: true}
+		queue := []string{root}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			group, ok := groups[current]
+			if !ok {
+				var err error
+				group, err = c.getGroup(ctx, current)
+				// Missing groups are reported by the API when the plan is applied.
+				if err != nil && !errors.Is(err, ErrNotFound) {
+					diags.AddAttributeWarning(
+						path.Root("nested_groups"),
+						"Could Not Check Nested Groups",
+						fmt.Sprintf("Could not read group %q to check nested_groups for cycles, unexpected error: %s", current, err.Error()),
+					)
+					return
+				}
+				groups[current] = group
+			}
+			if group == nil {
+				continue
+			}
+
+			for _, groupId := range group.Groups {
+				if groupId == id {
+					diags.AddAttributeError(
+						path.Root("nested_groups"),
+						"Invalid Nested Group",
+						fmt.Sprintf("Group %q already contains group %q, so nesting it here would form a cycle.", root, id),
+					)
+					queue = nil
+					break
+				}
+				if !visited[groupId] {
+					visited[groupId] = true
+					queue = append(queue, groupId)
+				}
+			}
+		}
+	}
+}
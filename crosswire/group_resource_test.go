@@ -0,0 +1,285 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGroupResource(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_group.%s", name)
+	nested_resource := fmt.Sprintf("crosswire_group.%s_nested", name)
+	policy_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGroupDestroy,
+		Steps: []resource.TestStep{
+			// Create and Read testing, with a policy approved by the group
+			{
+				Config: testAccGroupResourceConfig(name, "approver@company.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "name", name),
+					resource.TestCheckResourceAttr(terraform_resource, "members.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "members.*", map[string]string{
+						"email_address": "approver@company.com"}),
+					resource.TestCheckTypeSetElemAttrPair(terraform_resource, "nested_groups.*", nested_resource, "id"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "owners.*", map[string]string{
+						"email_address": "user@company.com"}),
+					resource.TestCheckResourceAttrSet(terraform_resource, "id"),
+					resource.TestCheckNoResourceAttr(nested_resource, "owners"),
+					resource.TestCheckResourceAttr(policy_resource, "group_approvers.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(policy_resource, "group_approvers.*", terraform_resource, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            policy_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccGroupResourceConfig(name, "second.approver@company.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "members.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "members.*", map[string]string{
+						"email_address": "second.approver@company.com"}),
+				),
+			},
+			// timeouts only affect Terraform
+			{
+				Config: testAccGroupResourceConfigTimeouts(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "timeouts.update", "2m"),
+					resource.TestCheckResourceAttr(terraform_resource, "members.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestCheckNestedGroups(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("id") {
+		case "parent":
+			_, _ = w.Write([]byte(`{"Id": "parent", "Name": "parent", "Groups": ["group"]}`))
+		case "grandparent":
+			_, _ = w.Write([]byte(`{"Id": "grandparent", "Name": "grandparent", "Groups": ["child", "loop"]}`))
+		case "loop":
+			_, _ = w.Write([]byte(`{"Id": "loop", "Name": "loop", "Groups": ["grandparent", "parent"]}`))
+		case "child":
+			_, _ = w.Write([]byte(`{"Id": "child", "Name": "child", "Groups": []}`))
+		case "broken":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		nested      []attr.Value
+		lenient     bool
+		wantErr     bool
+		wantWarning bool
+	}{
+		{
+			name:   "unrelated groups",
+			id:     "group",
+			nested: []attr.Value{types.StringValue("child"), types.StringValue("missing")},
+		},
+		{
+			name:    "self reference",
+			id:      "group",
+			nested:  []attr.Value{types.StringValue("group")},
+			wantErr: true,
+		},
+		{
+			name:    "self reference without strict_validation",
+			id:      "group",
+			nested:  []attr.Value{types.StringValue("group")},
+			lenient: true,
+			wantErr: true,
+		},
+		{
+			name:    "direct cycle",
+			id:      "group",
+			nested:  []attr.Value{types.StringValue("child"), types.StringValue("parent")},
+			wantErr: true,
+		},
+		{
+			name:    "longer cycle",
+			id:      "group",
+			nested:  []attr.Value{types.StringValue("grandparent")},
+			wantErr: true,
+		},
+		{
+			name:    "cycle without strict_validation",
+			id:      "group",
+			nested:  []attr.Value{types.StringValue("parent")},
+			lenient: true,
+		},
+		{
+			name:        "lookup error",
+			id:          "group",
+			nested:      []attr.Value{types.StringValue("broken")},
+			wantWarning: true,
+		},
+		{
+			name:   "unknown element",
+			id:     "group",
+			nested: []attr.Value{types.StringUnknown()},
+		},
+		{
+			name:   "new group",
+			nested: []attr.Value{types.StringValue("parent")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testClient(t, handler)
+			client.StrictValidation = !tt.lenient
+
+			var diags diag.Diagnostics
+			client.checkNestedGroups(context.Background(), tt.id, types.SetValueMust(types.StringType, tt.nested), &diags)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("checkNestedGroups() errors = %v, wantErr %v", diags.Errors(), tt.wantErr)
+			}
+			if (diags.WarningsCount() > 0) != tt.wantWarning {
+				t.Errorf("checkNestedGroups() warnings = %v, wantWarning %v", diags.Warnings(), tt.wantWarning)
+			}
+		})
+	}
+}
+
+func testAccCheckGroupDestroy(s *terraform.State) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "crosswire_group" {
+			continue
+		}
+
+		_, err := client.getGroup(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("group %s still exists", rs.Primary.ID)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	return testAccCheckPolicyDestroy(s)
+}
+
+func testAccGroupResourceConfig(name, member string) string {
+	return fmt.Sprintf(`
+resource "crosswire_group" "%[1]s_nested" {
+  name = "%[1]s-nested"
+  members = [
+    {
+      email_address = "user@company.com"
+    }
+  ]
+}
+
+resource "crosswire_group" "%[1]s" {
+  name        = "%[1]s"
+  description = "Approves production access"
+  members = [
+    {
+      email_address = "%[2]s"
+    }
+  ]
+  nested_groups = [crosswire_group.%[1]s_nested.id]
+  owners = [
+    {
+      email_address = "user@company.com"
+    }
+  ]
+}
+
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ADMIN"
+      }
+    ]
+  }
+  special_approver = "NONE"
+  group_approvers  = [crosswire_group.%[1]s.id]
+}
+`, name, member)
+}
+
+func testAccGroupResourceConfigTimeouts(name string) string {
+	return fmt.Sprintf(`
+resource "crosswire_group" "%[1]s_nested" {
+  name = "%[1]s-nested"
+  members = [
+    {
+      email_address = "user@company.com"
+    }
+  ]
+}
+
+resource "crosswire_group" "%[1]s" {
+  name        = "%[1]s"
+  description = "Approves production access"
+  members = [
+    {
+      email_address = "second.approver@company.com"
+    }
+  ]
+  nested_groups = [crosswire_group.%[1]s_nested.id]
+  owners = [
+    {
+      email_address = "user@company.com"
+    }
+  ]
+
+  timeouts {
+    create = "2m"
+    update = "2m"
+  }
+}
+`, name)
+}
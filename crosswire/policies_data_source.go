@@ -0,0 +1,185 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &PoliciesDataSource{}
+var _ datasource.DataSourceWithConfigure = &PoliciesDataSource{}
+
+func NewPoliciesDataSource() datasource.DataSource {
+	return &PoliciesDataSource{}
+}
+
+// PoliciesDataSource defines the data source implementation.
+type PoliciesDataSource struct {
+	client *Client
+}
+
+// PoliciesDataSourceModel describes the data source data model.
+type PoliciesDataSourceModel struct {
+	OwnerEmailAddress    types.String            `tfsdk:"owner_email_address"`
+	State                types.String            `tfsdk:"state"`
+	EntitlementProvider  types.String            `tfsdk:"entitlement_provider"`
+	EntitlementSubject   types.String            `tfsdk:"entitlement_subject"`
+	EntitlementObject    types.String            `tfsdk:"entitlement_object"`
+	ApproverEmailAddress types.String            `tfsdk:"approver_email_address"`
+	Policies             []PolicyDataSourceModel `tfsdk:"policies"`
+
+	Id types.String `tfsdk:"id"`
+}
+
+func (d *PoliciesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policies"
+}
+
+func (d *PoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Crosswire policies, optionally filtered. All filters that are set must match for a policy to be returned.",
+		Attributes: map[string]schema.Attribute{
+			"owner_email_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return policies owned by this email address. Case insensitive.",
+			},
+			"state": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return policies in this state, e.g. ACTIVE. Case insensitive.",
+			},
+			"entitlement_provider": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return policies granting an entitlement with this provider.",
+			},
+			"entitlement_subject": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return policies granting an entitlement with this subject.",
+			},
+			"entitlement_object": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return policies granting an entitlement with this object.",
+			},
+			"approver_email_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return policies listing this email address in user_approvers, or in the user_approvers of any approval stage. Case insensitive.",
+			},
+			"policies": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dataSourcePolicyAttributesV0(),
+				},
+				Description: "Matching policies, sorted by name and then id.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Placeholder identifier for this data source.",
+			},
+		},
+	}
+}
+
+func (d *PoliciesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := d.client.listPolicies(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Policies",
+			"Could not list policies, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Name != policies[j].Name {
+			return policies[i].Name < policies[j].Name
+		}
+		return policies[i].Id < policies[j].Id
+	})
+
+	data.Policies = []PolicyDataSourceModel{}
+	for i := range policies {
+		if data.matches(policies[i]) {
+			data.Policies = append(data.Policies, policyToDataSourceModelConverter(&policies[i]))
+		}
+	}
+	data.Id = types.StringValue("crosswire_policies")
+
+	tflog.Trace(ctx, "read a data source", map[string]any{"policies": len(data.Policies), "total": len(policies)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches reports whether policy satisfies every filter set on the model.
+func (data PoliciesDataSourceModel) matches(policy Policy) bool {
+	if !data.OwnerEmailAddress.IsNull() && !strings.EqualFold(policy.Owner, data.OwnerEmailAddress.ValueString()) {
+		return false
+	}
+	if !data.State.IsNull() && !strings.EqualFold(policy.State, data.State.ValueString()) {
+		return false
+	}
+
+	if !data.EntitlementProvider.IsNull() || !data.EntitlementSubject.IsNull() || !data.EntitlementObject.IsNull() {
+		found := false
+		for _, entitlement := range policy.Entitlements {
+			if (data.EntitlementProvider.IsNull() || entitlement.Provider == data.EntitlementProvider.ValueString()) &&
+				(data.EntitlementSubject.IsNull() || entitlement.Subject == data.EntitlementSubject.ValueString()) &&
+				(data.EntitlementObject.IsNull() || entitlement.Object == data.EntitlementObject.ValueString()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !data.ApproverEmailAddress.IsNull() {
+		approvers := [][]string{policy.UserApprovers}
+		for _, stage := range policy.ApprovalStages {
+			approvers = append(approvers, stage.UserApprovers)
+		}
+		found := false
+		for _, stageApprovers := range approvers {
+			for _, approver := range stageApprovers {
+				found = found || strings.EqualFold(approver, data.ApproverEmailAddress.ValueString())
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
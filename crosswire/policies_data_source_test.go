@@ -0,0 +1,150 @@
+package crosswire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPoliciesDataSource(t *testing.T) {
+	name := RandomStringGenerator(16)
+	other := RandomStringGenerator(16)
+	by_approver := fmt.Sprintf("data.crosswire_policies.%s_by_approver", name)
+	by_entitlement := fmt.Sprintf("data.crosswire_policies.%s_by_entitlement", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyResourceConfig(name) + testAccPolicyResourceConfigUpdated(other) + testAccPoliciesDataSourceConfig(name, other),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(by_approver, "policies.*", map[string]string{
+						"name":              other + "-updated",
+						"approval_behavior": "ALL",
+						"ttl":               "3600",
+						"max_ttl":           "1h",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(by_entitlement, "policies.*", map[string]string{
+						"name":                name,
+						"owner.email_address": "user@company.com",
+						"state":               "ACTIVE",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccPoliciesDataSourceConfig(name, other string) string {
+	return fmt.Sprintf(`
+data "crosswire_policies" "%[1]s_by_approver" {
+  approver_email_address = "second.approver@company.com"
+  state                  = "active"
+
+  depends_on = [crosswire_policy.%[1]s, crosswire_policy.%[2]s]
+}
+
+data "crosswire_policies" "%[1]s_by_entitlement" {
+  owner_email_address  = "USER@company.com"
+  entitlement_provider = "CROSSWIRE"
+  entitlement_object   = "PROPOSAL"
+
+  depends_on = [crosswire_policy.%[1]s, crosswire_policy.%[2]s]
+}
+`, name, other)
+}
+
+func TestPoliciesDataSourceModelMatches(t *testing.T) {
+	policy := Policy{
+		Owner: "owner@company.com",
+		State: "ACTIVE",
+		Entitlements: []Entitlement{
+			{Provider: "GITHUB", Subject: "TEAM", Object: "platform"},
+			{Provider: "AWS", Subject: "ROLE", Object: "admin"},
+		},
+		UserApprovers: []string{"approver@company.com"},
+		ApprovalStages: []ApprovalStage{
+			{SpecialApprover: ToPointer("MANAGER")},
+			{UserApprovers: []string{"stage.approver@company.com"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		model PoliciesDataSourceModel
+		want  bool
+	}{
+		{
+			name: "no filters",
+			want: true,
+		},
+		{
+			name:  "owner case insensitive",
+			model: PoliciesDataSourceModel{OwnerEmailAddress: types.StringValue("Owner@Company.com")},
+			want:  true,
+		},
+		{
+			name:  "other owner",
+			model: PoliciesDataSourceModel{OwnerEmailAddress: types.StringValue("someone@company.com")},
+			want:  false,
+		},
+		{
+			name:  "state",
+			model: PoliciesDataSourceModel{State: types.StringValue("active")},
+			want:  true,
+		},
+		{
+			name:  "other state",
+			model: PoliciesDataSourceModel{State: types.StringValue("ARCHIVED")},
+			want:  false,
+		},
+		{
+			name:  "entitlement provider",
+			model: PoliciesDataSourceModel{EntitlementProvider: types.StringValue("AWS")},
+			want:  true,
+		},
+		{
+			name: "entitlement fields must match the same entitlement",
+			model: PoliciesDataSourceModel{
+				EntitlementProvider: types.StringValue("AWS"),
+				EntitlementObject:   types.StringValue("platform"),
+			},
+			want: false,
+		},
+		{
+			name: "full entitlement",
+			model: PoliciesDataSourceModel{
+				EntitlementProvider: types.StringValue("GITHUB"),
+				EntitlementSubject:  types.StringValue("TEAM"),
+				EntitlementObject:   types.StringValue("platform"),
+			},
+			want: true,
+		},
+		{
+			name:  "approver",
+			model: PoliciesDataSourceModel{ApproverEmailAddress: types.StringValue("APPROVER@company.com")},
+			want:  true,
+		},
+		{
+			name:  "approval stage approver",
+			model: PoliciesDataSourceModel{ApproverEmailAddress: types.StringValue("Stage.Approver@company.com")},
+			want:  true,
+		},
+		{
+			name:  "other approver",
+			model: PoliciesDataSourceModel{ApproverEmailAddress: types.StringValue("owner@company.com")},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.matches(policy); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
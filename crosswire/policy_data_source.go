@@ -0,0 +1,405 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &PolicyDataSource{}
+var _ datasource.DataSourceWithConfigure = &PolicyDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &PolicyDataSource{}
+
+func NewPolicyDataSource() datasource.DataSource {
+	return &PolicyDataSource{}
+}
+
+// PolicyDataSource defines the data source implementation.
+type PolicyDataSource struct {
+	client *Client
+}
+
+// PolicyDataSourceModel describes the data source data model. It mirrors
+// PolicyResourceModel without the attributes that only affect Terraform.
+type PolicyDataSourceModel struct {
+	Owner                  UserModel            `tfsdk:"owner"`
+	Name                   types.String         `tfsdk:"name"`
+	Entitlements           []EntitlementModel   `tfsdk:"entitlements"`
+	Condition              types.Object         `tfsdk:"condition"`
+	ConditionJSON          types.String         `tfsdk:"condition_json"`
+	SpecialApprover        types.String         `tfsdk:"special_approver"`
+	ApprovalBehavior       types.String         `tfsdk:"approval_behavior"`
+	MinApprovals           types.Int64          `tfsdk:"min_approvals"`
+	UserApprovers          []UserModel          `tfsdk:"user_approvers"`
+	TTL                    types.Int64          `tfsdk:"ttl"`
+	DefaultTTL             types.String         `tfsdk:"default_ttl"`
+	MaxTTL                 types.String         `tfsdk:"max_ttl"`
+	AllowExtension         types.Bool           `tfsdk:"allow_extension"`
+	MaxExtensions          types.Int64          `tfsdk:"max_extensions"`
+	AccessWindows          []AccessWindowModel  `tfsdk:"access_windows"`
+	EntitlementApprovers   []EntitlementModel   `tfsdk:"entitlement_approvers"`
+	GroupApprovers         []types.String       `tfsdk:"group_approvers"`
+	ApprovalStages         []ApprovalStageModel `tfsdk:"approval_stages"`
+	RequireJustification   types.Bool           `tfsdk:"require_justification"`
+	JustificationMinLength types.Int64          `tfsdk:"justification_min_length"`
+	TicketPattern          types.String         `tfsdk:"ticket_pattern"`
+	TicketSystem           types.String         `tfsdk:"ticket_system"`
+
+	Id    types.String `tfsdk:"id"`
+	State types.String `tfsdk:"state"`
+}
+
+func (d *PolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+func dataSourceEntitlementSchemaV0() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"provider": schema.StringAttribute{Computed: true},
+			"subject":  schema.StringAttribute{Computed: true},
+			"object":   schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func dataSourceUserAttributesV0() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"email_address": schema.StringAttribute{Computed: true},
+	}
+}
+
+func dataSourceConditionSchemaV0(level int) schema.NestedAttributeObject {
+	attributes := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"quantifier": schema.StringAttribute{
+				Computed: true,
+				Description: `ANY only requires one of the entitlements or subconditions to be true in order for this condition block to be true while ALL requires all of them to be true.
+NONE requires all of them to be false; use it with a single entitlement to negate it.
+AT_LEAST requires at least threshold of them to be true.`,
+			},
+			"threshold": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of entitlements and subconditions that must be true when quantifier is AT_LEAST.",
+			},
+			"entitlements": schema.SetNestedAttribute{
+				Computed:     true,
+				NestedObject: dataSourceEntitlementSchemaV0(),
+				Description:  "Set of provider-subject-object tuples governing the truth value of this condition block.",
+			},
+		},
+	}
+	if level < maxConditionDepth-1 {
+		attributes.Attributes["subconditions"] = schema.SetNestedAttribute{
+			Computed:     true,
+			NestedObject: dataSourceConditionSchemaV0(level + 1),
+			Description:  "Set of subconditions governing the truth value of this condition block.",
+		}
+	}
+
+	return attributes
+}
+
+func dataSourceAccessWindowSchemaV0() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"days": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Days of the week the window opens on.",
+			},
+			"start_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time of day the window opens, in 24-hour HH:MM format.",
+			},
+			"end_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time of day the window closes. Windows closing before they open run past midnight.",
+			},
+			"time_zone": schema.StringAttribute{
+				Computed:    true,
+				Description: "IANA time zone of start_time and end_time.",
+			},
+		},
+	}
+}
+
+func dataSourceApprovalStageSchemaV0() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"special_approver": schema.StringAttribute{
+				Computed:    true,
+				Description: "One of NONE, AUTO, SELF or MANAGER. Null means NONE.",
+			},
+			"approval_behavior": schema.StringAttribute{
+				Computed:    true,
+				Description: "Whether ANY or ALL of the stage's approvers must approve. Null means ANY.",
+			},
+			"min_approvals": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of approvals the stage needs when approval_behavior is ANY.",
+			},
+			"user_approvers": schema.SetNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dataSourceUserAttributesV0(),
+				},
+				Description: "Set of users (email addresses) approving the stage.",
+			},
+			"entitlement_approvers": schema.SetNestedAttribute{
+				Computed:     true,
+				NestedObject: dataSourceEntitlementSchemaV0(),
+				Description:  "Set of provider-subject-object tuples whose users approve the stage.",
+			},
+			"group_approvers": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Set of group ids whose members approve the stage.",
+			},
+		},
+	}
+}
+
+// dataSourcePolicyAttributesV0 returns the read-only attributes describing a
+// policy, shared by the crosswire_policy and crosswire_policies data sources.
+func dataSourcePolicyAttributesV0() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "Crosswire policy id",
+		},
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "Name of the policy",
+		},
+		"owner": schema.SingleNestedAttribute{
+			Computed:    true,
+			Attributes:  dataSourceUserAttributesV0(),
+			Description: "Owner of the policy.",
+		},
+		"entitlements": schema.SetNestedAttribute{
+			Computed:     true,
+			NestedObject: dataSourceEntitlementSchemaV0(),
+			Description:  "Set of Provider-Subject-Object tuples users receive upon getting access to the policy.",
+		},
+		"condition": schema.SingleNestedAttribute{
+			Computed:    true,
+			Attributes:  dataSourceConditionSchemaV0(0).Attributes,
+			Description: fmt.Sprintf("Conditions necessary to become eligible for this policy. Null when the tree is deeper than %d levels; read condition_json instead.", maxConditionDepth),
+		},
+		"condition_json": schema.StringAttribute{
+			Computed:    true,
+			Description: "JSON encoding of the complete condition tree, in the format of the crosswire_policy resource's condition_json.",
+		},
+		"special_approver": schema.StringAttribute{
+			Computed:    true,
+			Description: "One of NONE, AUTO, SELF or MANAGER.",
+		},
+		"approval_behavior": schema.StringAttribute{
+			Computed:    true,
+			Description: "Whether ANY or ALL approvers must approve a request.",
+		},
+		"min_approvals": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Number of approvals a request needs when approval_behavior is ANY. Null when a single approval suffices.",
+		},
+		"user_approvers": schema.SetNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: dataSourceUserAttributesV0(),
+			},
+			Description: "Set of users (email addresses) who approve requests to this policy.",
+		},
+		"entitlement_approvers": schema.SetNestedAttribute{
+			Computed:     true,
+			NestedObject: dataSourceEntitlementSchemaV0(),
+			Description:  "Set of provider-subject-object tuples whose users approve requests to this policy.",
+		},
+		"group_approvers": schema.SetAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "Set of crosswire_group ids whose members approve requests to this policy.",
+		},
+		"approval_stages": schema.ListNestedAttribute{
+			Computed:     true,
+			NestedObject: dataSourceApprovalStageSchemaV0(),
+			Description:  "Ordered approval chain. Null when the policy uses the single-stage approver attributes.",
+		},
+		"require_justification": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether requesters must give a business reason for every request.",
+		},
+		"justification_min_length": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Minimum number of characters of a justification.",
+		},
+		"ticket_pattern": schema.StringAttribute{
+			Computed:    true,
+			Description: "Regular expression every request's change ticket must match.",
+		},
+		"ticket_system": schema.StringAttribute{
+			Computed:    true,
+			Description: "Ticketing integration request tickets are looked up in.",
+		},
+		"ttl": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Maximum number of seconds a user can hold the policy any given time",
+		},
+		"default_ttl": schema.StringAttribute{
+			Computed:    true,
+			Description: "How long access lasts when a request does not ask for a duration, e.g. `4h`.",
+		},
+		"max_ttl": schema.StringAttribute{
+			Computed:    true,
+			Description: "Longest a user can hold the policy any given time, e.g. `7d`. The same as ttl, as a duration.",
+		},
+		"allow_extension": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether users can extend their access before it expires.",
+		},
+		"max_extensions": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Maximum number of times a grant can be extended. Null for no limit.",
+		},
+		"access_windows": schema.ListNestedAttribute{
+			Computed:     true,
+			NestedObject: dataSourceAccessWindowSchemaV0(),
+			Description:  "Recurring windows during which grants of this policy can be used. Null when access is allowed at any time.",
+		},
+		"state": schema.StringAttribute{
+			Computed:    true,
+			Description: "Current state of the policy",
+		},
+	}
+}
+
+func (d *PolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataSourcePolicyAttributesV0()
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Crosswire policy id. Exactly one of id or name must be set.",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Exact name of the policy. Exactly one of id or name must be set.",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing Crosswire policy by `id` or by exact `name`.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *PolicyDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *PolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var id, name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var policy *Policy
+	var err error
+	if !id.IsNull() {
+		policy, err = d.client.getPolicy(ctx, id.ValueString())
+	} else {
+		policy, err = d.client.getPolicyByName(ctx, name.ValueString())
+	}
+	if errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Policy not found",
+			"No Crosswire policy matched the given id or name: "+err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Policies",
+			"Could not read policies, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state := policyToDataSourceModelConverter(policy)
+
+	tflog.Trace(ctx, "read a data source", map[string]any{"id": state.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func policyToDataSourceModelConverter(policy *Policy) PolicyDataSourceModel {
+	var policyModel PolicyResourceModel
+	policyToModelConverter(policy, &policyModel)
+	// The resource only fills in one of ttl and max_ttl, the data source
+	// reports both.
+	maxTTL := types.StringNull()
+	if policy.Ttl != nil && *policy.Ttl > 0 {
+		maxTTL = types.StringValue(formatDuration(*policy.Ttl))
+	}
+
+	return PolicyDataSourceModel{
+		Owner:                  policyModel.Owner,
+		Name:                   policyModel.Name,
+		Entitlements:           policyModel.Entitlements,
+		Condition:              policyModel.Condition,
+		ConditionJSON:          types.StringValue(conditionToJSON(policy.Condition)),
+		SpecialApprover:        policyModel.SpecialApprover,
+		ApprovalBehavior:       policyModel.ApprovalBehavior,
+		MinApprovals:           policyModel.MinApprovals,
+		UserApprovers:          policyModel.UserApprovers,
+		TTL:                    policyModel.TTL,
+		DefaultTTL:             policyModel.DefaultTTL.StringValue,
+		MaxTTL:                 maxTTL,
+		AllowExtension:         policyModel.AllowExtension,
+		MaxExtensions:          policyModel.MaxExtensions,
+		AccessWindows:          policyModel.AccessWindows,
+		EntitlementApprovers:   policyModel.EntitlementApprovers,
+		GroupApprovers:         policyModel.GroupApprovers,
+		ApprovalStages:         policyModel.ApprovalStages,
+		RequireJustification:   policyModel.RequireJustification,
+		JustificationMinLength: policyModel.JustificationMinLength,
+		TicketPattern:          policyModel.TicketPattern,
+		TicketSystem:           policyModel.TicketSystem,
+		Id:                     policyModel.Id,
+		State:                  policyModel.State,
+	}
+}
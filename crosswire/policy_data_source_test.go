@@ -0,0 +1,55 @@
+package crosswire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPolicyDataSource(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	by_id := fmt.Sprintf("data.crosswire_policy.%s_by_id", name)
+	by_name := fmt.Sprintf("data.crosswire_policy.%s_by_name", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyResourceConfig(name) + testAccPolicyDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(by_id, "id", terraform_resource, "id"),
+					resource.TestCheckResourceAttr(by_id, "name", name),
+					resource.TestCheckResourceAttr(by_id, "owner.email_address", "user@company.com"),
+					resource.TestCheckResourceAttr(by_id, "entitlements.#", "2"),
+					resource.TestCheckResourceAttr(by_id, "condition.quantifier", "ANY"),
+					resource.TestCheckResourceAttr(by_id, "condition.subconditions.0.entitlements.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(by_id, "user_approvers.*", map[string]string{
+						"email_address": "approver@company.com"}),
+					resource.TestCheckTypeSetElemNestedAttrs(by_id, "entitlement_approvers.*", map[string]string{
+						"provider": "CROSSWIRE", "subject": "APPROVE", "object": "PROPOSAL"}),
+					resource.TestCheckResourceAttr(by_id, "approval_behavior", "ANY"),
+					resource.TestCheckResourceAttr(by_id, "special_approver", "NONE"),
+					resource.TestCheckResourceAttr(by_id, "state", "ACTIVE"),
+					resource.TestCheckNoResourceAttr(by_id, "ttl"),
+					resource.TestCheckResourceAttrPair(by_name, "id", terraform_resource, "id"),
+					resource.TestCheckResourceAttr(by_name, "name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicyDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+data "crosswire_policy" "%[1]s_by_id" {
+  id = crosswire_policy.%[1]s.id
+}
+
+data "crosswire_policy" "%[1]s_by_name" {
+  name = crosswire_policy.%[1]s.name
+}
+`, name)
+}
@@ -2,23 +2,37 @@ package crosswire
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// defaultPolicyTimeout applies to each operation when no timeouts block is set.
+const defaultPolicyTimeout = 5 * time.Minute
+
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &PolicyResource{}
 var _ resource.ResourceWithConfigure = &PolicyResource{}
+var _ resource.ResourceWithModifyPlan = &PolicyResource{}
+var _ resource.ResourceWithConfigValidators = &PolicyResource{}
 
 func NewPolicyResource() resource.Resource {
 	return &PolicyResource{}
@@ -31,15 +45,30 @@ type PolicyResource struct {
 
 // ExampleResourceModel describes the resource data model.
 type PolicyResourceModel struct {
-	Owner                UserModel          `tfsdk:"owner"`
-	Name                 types.String       `tfsdk:"name"`
-	Entitlements         []EntitlementModel `tfsdk:"entitlements"`
-	Condition            ConditionModel     `tfsdk:"condition"`
-	SpecialApprover      types.String       `tfsdk:"special_approver"`
-	ApprovalBehavior     types.String       `tfsdk:"approval_behavior"`
-	UserApprovers        []UserModel        `tfsdk:"user_approvers"`
-	TTL                  types.Int64        `tfsdk:"ttl"`
-	EntitlementApprovers []EntitlementModel `tfsdk:"entitlement_approvers"`
+	Owner                  UserModel            `tfsdk:"owner"`
+	Name                   types.String         `tfsdk:"name"`
+	Entitlements           []EntitlementModel   `tfsdk:"entitlements"`
+	Condition              types.Object         `tfsdk:"condition"`
+	ConditionJSON          types.String         `tfsdk:"condition_json"`
+	SpecialApprover        types.String         `tfsdk:"special_approver"`
+	ApprovalBehavior       types.String         `tfsdk:"approval_behavior"`
+	MinApprovals           types.Int64          `tfsdk:"min_approvals"`
+	UserApprovers          []UserModel          `tfsdk:"user_approvers"`
+	TTL                    types.Int64          `tfsdk:"ttl"`
+	DefaultTTL             DurationValue        `tfsdk:"default_ttl"`
+	MaxTTL                 DurationValue        `tfsdk:"max_ttl"`
+	AllowExtension         types.Bool           `tfsdk:"allow_extension"`
+	MaxExtensions          types.Int64          `tfsdk:"max_extensions"`
+	AccessWindows          []AccessWindowModel  `tfsdk:"access_windows"`
+	EntitlementApprovers   []EntitlementModel   `tfsdk:"entitlement_approvers"`
+	GroupApprovers         []types.String       `tfsdk:"group_approvers"`
+	ApprovalStages         []ApprovalStageModel `tfsdk:"approval_stages"`
+	RequireJustification   types.Bool           `tfsdk:"require_justification"`
+	JustificationMinLength types.Int64          `tfsdk:"justification_min_length"`
+	TicketPattern          types.String         `tfsdk:"ticket_pattern"`
+	TicketSystem           types.String         `tfsdk:"ticket_system"`
+	RevocationBehavior     types.String         `tfsdk:"revocation_behavior"`
+	Timeouts               timeouts.Value       `tfsdk:"timeouts"`
 
 	Id          types.String `tfsdk:"id"`
 	State       types.String `tfsdk:"state"`
@@ -56,10 +85,20 @@ type UserModel struct {
 	EmailAddress types.String `tfsdk:"email_address"`
 }
 
-type ConditionModel struct {
-	Quantifier    types.String       `tfsdk:"quantifier"`
-	Entitlements  []EntitlementModel `tfsdk:"entitlements"`
-	Subconditions []ConditionModel   `tfsdk:"subconditions"`
+type ApprovalStageModel struct {
+	SpecialApprover      types.String       `tfsdk:"special_approver"`
+	ApprovalBehavior     types.String       `tfsdk:"approval_behavior"`
+	MinApprovals         types.Int64        `tfsdk:"min_approvals"`
+	UserApprovers        []UserModel        `tfsdk:"user_approvers"`
+	EntitlementApprovers []EntitlementModel `tfsdk:"entitlement_approvers"`
+	GroupApprovers       []types.String     `tfsdk:"group_approvers"`
+}
+
+type AccessWindowModel struct {
+	Days      []types.String `tfsdk:"days"`
+	StartTime types.String   `tfsdk:"start_time"`
+	EndTime   types.String   `tfsdk:"end_time"`
+	TimeZone  types.String   `tfsdk:"time_zone"`
 }
 
 func (p *PolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -95,9 +134,15 @@ func attributeConditionSchemaV0(level int) schema.NestedAttributeObject {
 			"quantifier": schema.StringAttribute{
 				Required: true,
 				Validators: []validator.String{
-					stringvalidator.OneOfCaseInsensitive("ANY", "ALL"),
+					stringvalidator.OneOfCaseInsensitive(conditionQuantifiers...),
 				},
-				Description: "ANY only requires one of the entitlements or subconditions to be `true` in order for this condition block to be true while ALL requires all of them to be true.",
+				Description: `ANY only requires one of the entitlements or subconditions to be true in order for this condition block to be true while ALL requires all of them to be true.
+NONE requires all of them to be false; use it with a single entitlement to negate it.
+AT_LEAST requires at least threshold of them to be true.`,
+			},
+			"threshold": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of entitlements and subconditions that must be true when quantifier is AT_LEAST. Only valid with AT_LEAST.",
 			},
 			"entitlements": schema.SetNestedAttribute{
 				Optional:     true,
@@ -106,20 +151,107 @@ func attributeConditionSchemaV0(level int) schema.NestedAttributeObject {
 			},
 		},
 	}
-	if level < 2 {
+	if level < maxConditionDepth-1 {
 		attributes.Attributes["subconditions"] = schema.SetNestedAttribute{
 			Optional:     true,
 			NestedObject: attributeConditionSchemaV0(level + 1),
-			Description:  "Set of subconditions governing the truth value of this condition block. If you need more than 3 levels of subconditions, please contact someone at Crosswire for assistance.",
+			Description:  fmt.Sprintf("Set of subconditions governing the truth value of this condition block. For more than %d levels of subconditions, use condition_json instead.", maxConditionDepth),
 		}
 	}
 
 	return attributes
 }
 
+// flatApproverAttributes are the single-stage shorthand for approval_stages.
+var flatApproverAttributes = []string{
+	"special_approver", "approval_behavior", "min_approvals",
+	"user_approvers", "entitlement_approvers", "group_approvers",
+}
+
+func attributeAccessWindowSchemaV0() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"days": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOfCaseInsensitive(accessWindowDays...)),
+				},
+				Description: fmt.Sprintf("Days of the week the window opens on, any of %s.", strings.Join(accessWindowDays, ", ")),
+			},
+			"start_time": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(startTimePattern, "value must be a 24-hour time such as 09:00"),
+				},
+				Description: "Time of day the window opens, in 24-hour HH:MM format, e.g. `09:00`.",
+			},
+			"end_time": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(endTimePattern, "value must be a 24-hour time such as 17:30, or 24:00"),
+				},
+				Description: "Time of day the window closes, e.g. `17:30`, or `24:00` for midnight. Windows closing before they open run past midnight into the next day.",
+			},
+			"time_zone": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					timeZoneValidator{},
+				},
+				Description: "IANA time zone of start_time and end_time, e.g. `Europe/London`, so windows follow daylight saving time.",
+			},
+		},
+	}
+}
+
+func attributeApprovalStageSchemaV0() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"special_approver": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("NONE", "AUTO", "SELF", "MANAGER"),
+				},
+				Description: "Like the policy's special_approver, for this stage. Omit for NONE.",
+			},
+			"approval_behavior": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("ANY", "ALL"),
+				},
+				Description: "Whether ANY or ALL of this stage's approvers must approve. Omit for ANY.",
+			},
+			"min_approvals": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of approvals this stage needs when approval_behavior is ANY.",
+			},
+			"user_approvers": schema.SetNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userAttributesV0(),
+				},
+				Description: "Set of users (email addresses) approving this stage.",
+			},
+			"entitlement_approvers": schema.SetNestedAttribute{
+				Optional:     true,
+				NestedObject: attributeEntitlementSchemaV0(),
+				Description:  "Set of provider-subject-object tuples whose users approve this stage.",
+			},
+			"group_approvers": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Set of crosswire_group ids whose members approve this stage.",
+			},
+		},
+	}
+}
+
 func (p *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Policy resource",
+		MarkdownDescription: "Policy resource\n\n" +
+			"Enum attributes such as `special_approver`, `approval_behavior` and every condition `quantifier` accept any case. " +
+			"Crosswire stores them upper-cased, and the provider keeps the configured spelling in state when it matches, so mixed-case values do not cause a diff.",
 		Attributes: map[string]schema.Attribute{
 			"owner": schema.SingleNestedAttribute{
 				Required:    true,
@@ -136,9 +268,18 @@ func (p *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description:  "Set of Provider-Subject-Object tuples corresponding to what access users will receive upon getting access to the policy.",
 			},
 			"condition": schema.SingleNestedAttribute{
-				Required:    true,
+				Optional:    true,
 				Attributes:  attributeConditionSchemaV0(0).Attributes,
-				Description: "Conditions necessary to become eligible for this policy.",
+				Description: "Conditions necessary to become eligible for this policy. Exactly one of condition or condition_json must be set.",
+			},
+			"condition_json": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					conditionJSONValidator{},
+				},
+				Description: `JSON encoded alternative to condition for trees of any depth, typically built with jsonencode().
+It takes the same shape as condition: an object with quantifier, threshold, entitlements (objects with provider, subject and object) and subconditions.
+Exactly one of condition or condition_json must be set.`,
 			},
 			"special_approver": schema.StringAttribute{
 				Optional: true,
@@ -152,7 +293,7 @@ func (p *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: `AUTO will automatically grant the policy if eligible.
 Self will grant the policy once requested.
 Manager requires the subject's manager to approve access.
-If this is set to anything besides "NONE", don't set user_approvers or entitlement_approvers.`,
+If this is set to anything besides "NONE", don't set user_approvers, entitlement_approvers or group_approvers.`,
 			},
 			"approval_behavior": schema.StringAttribute{
 				Optional: true,
@@ -164,7 +305,13 @@ If this is set to anything besides "NONE", don't set user_approvers or entitleme
 					stringvalidator.OneOfCaseInsensitive("ANY", "ALL"),
 				},
 				Description: `ANY requires only one approval from the set of approvers specified
-ALL requires approvals from every approver in order to gain access. When selecting this, make sure to have a small number of approvers to reduce in-flight time to gain access.`,
+ALL requires approvals from every approver in order to gain access. When selecting this, make sure to have a small number of approvers to reduce in-flight time to gain access.
+To require a number of approvals between the two, keep ANY and set min_approvals.`,
+			},
+			"min_approvals": schema.Int64Attribute{
+				Optional: true,
+				Description: `Number of approvals a request needs when approval_behavior is ANY, e.g. 2 to require any 2 of the approvers. Omit to require a single approval.
+Cannot exceed the number of user_approvers when they are the only approvers.`,
 			},
 			"user_approvers": schema.SetNestedAttribute{
 				Optional: true,
@@ -178,14 +325,108 @@ ALL requires approvals from every approver in order to gain access. When selecti
 				NestedObject: attributeEntitlementSchemaV0(),
 				Description: `Set of provider-subject-object tuples whose users will be approving requests to this policy.
 Typically these would be group memberships rather than application access.`,
+			},
+			"group_approvers": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Set of crosswire_group ids whose members will be approving requests to this policy.",
+			},
+			"approval_stages": schema.ListNestedAttribute{
+				Optional:     true,
+				NestedObject: attributeApprovalStageSchemaV0(),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(flatApproverExpressions()...),
+				},
+				Description: `Ordered approval chain, e.g. the requester's manager first and then the security on-call. Each stage must approve a request before it moves on to the next.
+The top-level special_approver, approval_behavior, min_approvals and approver attributes are a shorthand for a single stage and cannot be set together with approval_stages.`,
 			},
 			"ttl": schema.Int64Attribute{
 				Optional:    true,
-				Description: "Maximum number of seconds a user can hold the policy any given time",
+				Description: fmt.Sprintf("Maximum number of seconds a user can hold the policy any given time, between %d and %d. Omit for access that does not expire. Set either ttl or max_ttl; imported and exported policies use ttl.", minPolicyTTL, maxPolicyTTL),
+			},
+			"default_ttl": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  DurationType{},
+				Description: fmt.Sprintf("How long access lasts when a request does not ask for a duration, e.g. `4h` or `90m`. Must be between %s and %s and no longer than max_ttl.", formatDuration(minPolicyTTL), formatDuration(maxPolicyTTL)),
+			},
+			"max_ttl": schema.StringAttribute{
+				Optional:   true,
+				CustomType: DurationType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ttl")),
+				},
+				Description: fmt.Sprintf("Longest a user can hold the policy any given time, e.g. `8h` or `7d`, between %s and %s. Omit for access that does not expire. Imported policies set ttl instead, so switching their configuration to max_ttl plans an update that leaves the policy unchanged.", formatDuration(minPolicyTTL), formatDuration(maxPolicyTTL)),
+			},
+			"allow_extension": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					BoolDefault(false),
+				},
+				Description: "Whether users can extend their access before it expires, without a new approval. Requires a TTL. Defaults to false.",
+			},
+			"max_extensions": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Maximum number of times a grant can be extended. Requires allow_extension to be true. Omit for no limit.",
+			},
+			"access_windows": schema.ListNestedAttribute{
+				Optional:     true,
+				NestedObject: attributeAccessWindowSchemaV0(),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				Description: `Recurring windows, e.g. business hours, during which grants of this policy can be used. Omit to allow access at any time.
+Windows in the same time zone cannot overlap.`,
+			},
+			"require_justification": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					BoolDefault(false),
+				},
+				Description: "Whether requesters must give a business reason for every request. Defaults to false.",
+			},
+			"justification_min_length": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Minimum number of characters of a justification. Requires require_justification to be true.",
+			},
+			"ticket_pattern": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					regexpValidator{},
+				},
+				Description: "RE2 regular expression every request's change ticket must match, e.g. `^CHG-[0-9]+$`. Omit to not require a ticket unless ticket_system is set.",
+			},
+			"ticket_system": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the ticketing integration in Crosswire, e.g. JIRA or SERVICENOW, that request tickets are looked up in. Setting it requires a ticket on every request.",
+			},
+			"revocation_behavior": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					StringDefault("REVOKE"),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("REVOKE", "EXPIRE", "ARCHIVE"),
+				},
+				Description: `What happens to users currently holding the policy's entitlements when the policy is destroyed.
+REVOKE removes their access immediately.
+EXPIRE deletes the policy but lets existing grants run until their TTL elapses.
+ARCHIVE keeps the policy in Crosswire in a disabled state instead of deleting it.`,
 			},
 			"id": schema.StringAttribute{
 				Computed: true,
-				// Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 				Description: "Crosswire policy id",
 			},
 			"state": schema.StringAttribute{
@@ -197,38 +438,125 @@ Typically these would be group memberships rather than application access.`,
 				Description: "Timestamp Terraform received the policy's latest update",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-func (p PolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data PolicyResourceModel
+func (p PolicyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("condition"),
+			path.MatchRoot("condition_json"),
+		),
+	}
+}
+
+func flatApproverExpressions() []path.Expression {
+	var expressions []path.Expression
+	for _, name := range flatApproverAttributes {
+		expressions = append(expressions, path.MatchRoot(name))
+	}
+	return expressions
+}
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+func (p PolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Read attributes individually, as collections may still be unknown when
+	// they come from other resources or data sources.
+	var config policyConfig
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("special_approver"), &config.SpecialApprover)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("approval_behavior"), &config.ApprovalBehavior)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min_approvals"), &config.MinApprovals)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &config.TTL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("default_ttl"), &config.DefaultTTL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_ttl"), &config.MaxTTL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_extension"), &config.AllowExtension)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_extensions"), &config.MaxExtensions)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("access_windows"), &config.AccessWindows)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entitlements"), &config.Entitlements)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("condition"), &config.Condition)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_approvers"), &config.UserApprovers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entitlement_approvers"), &config.EntitlementApprovers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("group_approvers"), &config.GroupApprovers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("approval_stages"), &config.ApprovalStages)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("require_justification"), &config.RequireJustification)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("justification_min_length"), &config.JustificationMinLength)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if data.TTL.ValueInt64() > 0 && data.SpecialApprover.ValueString() == "AUTO" {
-		resp.Diagnostics.AddError(
-			"Auto policies cannot have a TTL",
-			"Because users are granted access automatically, TTL is redundant as it would continue to be regranted until the user no longer qualifies, at which time, the user would lose access immediately regardless of time remaining.",
-		)
+	resp.Diagnostics.Append(validatePolicyConfig(config)...)
+}
+
+// ModifyPlan checks the owner and every approver against Crosswire users
+// unless the provider has validate_users disabled, and every entitlement the
+// policy references against the catalog when it has strict_validation enabled.
+func (p *PolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying or before the provider is configured.
+	if req.Plan.Raw.IsNull() || p.client == nil {
+		return
 	}
 
-	if data.SpecialApprover.ValueString() == "NONE" && len(data.EntitlementApprovers) == 0 && len(data.UserApprovers) == 0 {
+	var stages types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("approval_stages"), &stages)...)
+
+	if p.client.ValidateUsers {
+		var references []userReference
+		var owner types.String
+		ownerPath := path.Root("owner").AtName("email_address")
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, ownerPath, &owner)...)
+		if !owner.IsNull() && !owner.IsUnknown() {
+			references = append(references, userReference{owner.ValueString(), ownerPath})
+		}
+
+		var approvers types.Set
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("user_approvers"), &approvers)...)
+		references = append(references, userSetReferences(approvers, path.Root("user_approvers"))...)
+		for _, stage := range approvalStageConfigs(stages) {
+			references = append(references, userSetReferences(stage.UserApprovers, stage.Path.AtName("user_approvers"))...)
+		}
+		p.client.checkUsers(ctx, references, &resp.Diagnostics)
+	}
+
+	if !p.client.StrictValidation {
+		return
+	}
+
+	if err := p.client.loadEntitlementCatalog(ctx); err != nil {
 		resp.Diagnostics.AddError(
-			"No approvers selected",
-			"At least one approver needs to be set to approve policy requests",
+			"Error Reading Entitlement Catalog",
+			"Could not list entitlements for strict_validation, unexpected error: "+err.Error(),
 		)
+		return
 	}
 
-	if len(data.Entitlements) == 0 {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("entitlements"),
-			"No entitlements selected",
-			"At least one entitlement needs to be set for the policy to function",
-		)
+	for _, name := range []string{"entitlements", "entitlement_approvers"} {
+		var entitlements types.Set
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &entitlements)...)
+		p.client.checkCatalogEntitlements(entitlements, path.Root(name), &resp.Diagnostics)
+	}
+	for _, stage := range approvalStageConfigs(stages) {
+		p.client.checkCatalogEntitlements(stage.EntitlementApprovers, stage.Path.AtName("entitlement_approvers"), &resp.Diagnostics)
+	}
+
+	var condition types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("condition"), &condition)...)
+	p.client.checkCatalogCondition(condition, path.Root("condition"), &resp.Diagnostics)
+
+	var encoded types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("condition_json"), &encoded)...)
+	if !encoded.IsNull() && !encoded.IsUnknown() {
+		// Invalid JSON has already been reported by the attribute's validator.
+		if tree, err := conditionFromJSON(encoded.ValueString()); err == nil {
+			p.client.checkCatalogConditionTree(tree, path.Root("condition_json"), &resp.Diagnostics)
+		}
 	}
 }
 
@@ -261,51 +589,26 @@ func (p *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	entitlementsFromModelConverter := func(modelEntitlements []EntitlementModel) []Entitlement {
-		var entitlements []Entitlement
-		for _, entitlement := range modelEntitlements {
-			entitlements = append(entitlements, Entitlement{
-				Provider: entitlement.Provider.ValueString(),
-				Subject:  entitlement.Subject.ValueString(),
-				Object:   entitlement.Object.ValueString(),
-			})
-		}
-		return entitlements
-	}
-
-	var conditionFromModelConverter func(ConditionModel) Condition
-	conditionFromModelConverter = func(conditionModel ConditionModel) Condition {
-		condition := Condition{
-			Quantifier:   conditionModel.Quantifier.ValueString(),
-			Entitlements: entitlementsFromModelConverter(conditionModel.Entitlements),
-		}
-		if len(conditionModel.Subconditions) > 0 {
-			for _, subcondition := range conditionModel.Subconditions {
-				condition.Subconditions = append(condition.Subconditions, conditionFromModelConverter(subcondition))
-			}
-		}
-
-		return condition
-	}
-
-	var userApprovers []string
-	for _, user := range data.UserApprovers {
-		userApprovers = append(userApprovers, user.EmailAddress.ValueString())
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	// Generate API request body from plan
-	policy := Policy{
-		Owner:                data.Owner.EmailAddress.ValueString(),
-		Name:                 data.Name.ValueString(),
-		Entitlements:         entitlementsFromModelConverter(data.Entitlements),
-		Condition:            conditionFromModelConverter(data.Condition),
-		SpecialApprover:      ToPointer(data.SpecialApprover.ValueString()),
-		ApprovalBehavior:     ToPointer(data.ApprovalBehavior.ValueString()),
-		UserApprovers:        userApprovers,
-		EntitlementApprovers: entitlementsFromModelConverter(data.EntitlementApprovers),
+	policy, err := policyFromModelConverter(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("condition_json"),
+			"Invalid condition_json",
+			"Could not decode the condition: "+err.Error(),
+		)
+		return
 	}
 
-	createdPolicy, err := p.client.createPolicy(policy)
+	createdPolicy, err := p.client.createPolicy(ctx, policy)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating policy",
@@ -314,41 +617,7 @@ func (p *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	if !data.TTL.IsUnknown() {
-		policy.Ttl = ToPointer(data.TTL.ValueInt64())
-	}
-
-	entitlementsToModelConverter := func(entitlements []Entitlement) []EntitlementModel {
-		var entitlementsModel []EntitlementModel
-		for _, entitlement := range entitlements {
-			entitlementsModel = append(entitlementsModel, EntitlementModel{
-				Provider: types.StringValue(entitlement.Provider),
-				Subject:  types.StringValue(entitlement.Subject),
-				Object:   types.StringValue(entitlement.Object),
-			})
-		}
-		return entitlementsModel
-	}
-
-	var userApproversModel []UserModel
-	for _, user := range createdPolicy.UserApprovers {
-		userApproversModel = append(userApproversModel, UserModel{EmailAddress: types.StringValue(user)})
-	}
-
-	data.Owner = UserModel{EmailAddress: types.StringValue(createdPolicy.Owner)}
-	data.Name = types.StringValue(createdPolicy.Name)
-	data.Entitlements = entitlementsToModelConverter(createdPolicy.Entitlements)
-	data.Condition = conditionToModelConverter(createdPolicy.Condition)
-	if createdPolicy.SpecialApprover != nil {
-		data.SpecialApprover = types.StringValue(*createdPolicy.SpecialApprover)
-	}
-	if createdPolicy.ApprovalBehavior != nil {
-		data.ApprovalBehavior = types.StringValue(*createdPolicy.ApprovalBehavior)
-	}
-	data.UserApprovers = userApproversModel
-	data.EntitlementApprovers = entitlementsToModelConverter(createdPolicy.EntitlementApprovers)
-	data.Id = types.StringValue(createdPolicy.Id)
-	data.State = types.StringValue(createdPolicy.State)
+	policyToModelConverter(createdPolicy, &data)
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Write logs using the tflog package
@@ -370,8 +639,21 @@ func (p *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Look up policy from Crosswire
-	policy, err := p.client.getPolicy(state.Id.ValueString())
+	policy, err := p.client.getPolicy(ctx, state.Id.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Policy no longer exists in Crosswire, removing from state", map[string]any{"id": state.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Policies",
@@ -380,26 +662,8 @@ func (p *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	if policy == nil {
-		return
-	}
-
 	// Overwrite items with refreshed state
-	var userApproversModel []UserModel
-	for _, user := range policy.UserApprovers {
-		userApproversModel = append(userApproversModel, UserModel{EmailAddress: types.StringValue(user)})
-	}
-
-	state.Owner = UserModel{EmailAddress: types.StringValue(policy.Owner)}
-	state.Name = types.StringValue(policy.Name)
-	state.Entitlements = entitlementsToModelConverter(policy.Entitlements)
-	state.Condition = conditionToModelConverter(policy.Condition) //ConditionModel{Quantifier: types.StringValue(policy.Condition.Quantifier), Entitlements: conditions.Entitlements, Subconditions: []ConditionModel{{Quantifier: types.StringValue("ahh")}}} //conditionToModelConverter(policy.Condition)
-	state.SpecialApprover = types.StringValue(*policy.SpecialApprover)
-	state.ApprovalBehavior = types.StringValue(*policy.ApprovalBehavior)
-	state.UserApprovers = userApproversModel
-	state.EntitlementApprovers = entitlementsToModelConverter(policy.EntitlementApprovers)
-	state.Id = types.StringValue(policy.Id)
-	state.State = types.StringValue(policy.State)
+	policyToModelConverter(policy, &state)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -409,6 +673,257 @@ func (p *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 }
 
+func policyFromModelConverter(data PolicyResourceModel) (Policy, error) {
+	condition := conditionFromObjectValue(data.Condition)
+	if !data.ConditionJSON.IsNull() {
+		var err error
+		if condition, err = conditionFromJSON(data.ConditionJSON.ValueString()); err != nil {
+			return Policy{}, err
+		}
+	}
+
+	policy := Policy{
+		Owner:                data.Owner.EmailAddress.ValueString(),
+		Name:                 data.Name.ValueString(),
+		Entitlements:         entitlementsFromModelConverter(data.Entitlements),
+		Condition:            condition,
+		SpecialApprover:      ToPointer(data.SpecialApprover.ValueString()),
+		ApprovalBehavior:     ToPointer(data.ApprovalBehavior.ValueString()),
+		UserApprovers:        usersFromModelConverter(data.UserApprovers),
+		EntitlementApprovers: entitlementsFromModelConverter(data.EntitlementApprovers),
+		GroupApprovers:       stringsFromModelConverter(data.GroupApprovers),
+		RequireJustification: data.RequireJustification.ValueBool(),
+		TicketPattern:        data.TicketPattern.ValueString(),
+		TicketSystem:         data.TicketSystem.ValueString(),
+		AllowExtension:       data.AllowExtension.ValueBool(),
+	}
+	if !data.JustificationMinLength.IsNull() && !data.JustificationMinLength.IsUnknown() {
+		policy.JustificationMinLength = ToPointer(data.JustificationMinLength.ValueInt64())
+	}
+	for _, stage := range data.ApprovalStages {
+		policy.ApprovalStages = append(policy.ApprovalStages, approvalStageFromModelConverter(stage))
+	}
+	if !data.MinApprovals.IsNull() && !data.MinApprovals.IsUnknown() {
+		policy.MinApprovals = ToPointer(data.MinApprovals.ValueInt64())
+	}
+	// ttl and max_ttl are both the maximum, and cannot be set together.
+	if !data.TTL.IsNull() && !data.TTL.IsUnknown() {
+		policy.Ttl = ToPointer(data.TTL.ValueInt64())
+	}
+	if maxTTL, ok := data.MaxTTL.Seconds(); ok {
+		policy.Ttl = ToPointer(maxTTL)
+	}
+	if defaultTTL, ok := data.DefaultTTL.Seconds(); ok {
+		policy.DefaultTtl = ToPointer(defaultTTL)
+	}
+	if !data.MaxExtensions.IsNull() && !data.MaxExtensions.IsUnknown() {
+		policy.MaxExtensions = ToPointer(data.MaxExtensions.ValueInt64())
+	}
+	for _, window := range data.AccessWindows {
+		policy.AccessWindows = append(policy.AccessWindows, AccessWindow{
+			Days:      stringsFromModelConverter(window.Days),
+			StartTime: window.StartTime.ValueString(),
+			EndTime:   window.EndTime.ValueString(),
+			TimeZone:  window.TimeZone.ValueString(),
+		})
+	}
+
+	return policy, nil
+}
+
+func policyToModelConverter(policy *Policy, data *PolicyResourceModel) {
+	data.Owner = UserModel{EmailAddress: types.StringValue(policy.Owner)}
+	data.Name = types.StringValue(policy.Name)
+	data.Entitlements = entitlementsToModelConverter(policy.Entitlements)
+	conditionToModelConverter(policy.Condition, data)
+	// Policies with approval_stages may omit the shorthand's enums, which then
+	// hold their defaults.
+	data.SpecialApprover = preserveCase(data.SpecialApprover, valueOrDefault(policy.SpecialApprover, "NONE"))
+	data.ApprovalBehavior = preserveCase(data.ApprovalBehavior, valueOrDefault(policy.ApprovalBehavior, "ANY"))
+	if policy.MinApprovals != nil && *policy.MinApprovals > 0 {
+		data.MinApprovals = types.Int64Value(*policy.MinApprovals)
+	} else {
+		data.MinApprovals = types.Int64Null()
+	}
+	data.UserApprovers = usersToModelConverter(policy.UserApprovers)
+	data.EntitlementApprovers = entitlementsToModelConverter(policy.EntitlementApprovers)
+	data.GroupApprovers = stringsToModelConverter(policy.GroupApprovers)
+	current := data.ApprovalStages
+	data.ApprovalStages = nil
+	for i, stage := range policy.ApprovalStages {
+		var currentStage ApprovalStageModel
+		if i < len(current) {
+			currentStage = current[i]
+		}
+		data.ApprovalStages = append(data.ApprovalStages, approvalStageToModelConverter(stage, currentStage))
+	}
+	data.RequireJustification = types.BoolValue(policy.RequireJustification)
+	if policy.JustificationMinLength != nil && *policy.JustificationMinLength > 0 {
+		data.JustificationMinLength = types.Int64Value(*policy.JustificationMinLength)
+	} else {
+		data.JustificationMinLength = types.Int64Null()
+	}
+	data.TicketPattern = optionalStringValue(policy.TicketPattern)
+	data.TicketSystem = optionalStringValue(policy.TicketSystem)
+	// ttl and max_ttl both set the API's Ttl. Keep max_ttl when it is
+	// configured, and use ttl otherwise, including on import.
+	useMaxTTL, currentMaxTTL := !data.MaxTTL.IsNull(), data.MaxTTL
+	data.TTL, data.MaxTTL = types.Int64Null(), DurationNull()
+	if policy.Ttl != nil && *policy.Ttl > 0 {
+		if useMaxTTL {
+			data.MaxTTL = durationValue(currentMaxTTL, *policy.Ttl)
+		} else {
+			data.TTL = types.Int64Value(*policy.Ttl)
+		}
+	}
+	if policy.DefaultTtl != nil && *policy.DefaultTtl > 0 {
+		data.DefaultTTL = durationValue(data.DefaultTTL, *policy.DefaultTtl)
+	} else {
+		data.DefaultTTL = DurationNull()
+	}
+	data.AllowExtension = types.BoolValue(policy.AllowExtension)
+	if policy.MaxExtensions != nil && *policy.MaxExtensions > 0 {
+		data.MaxExtensions = types.Int64Value(*policy.MaxExtensions)
+	} else {
+		data.MaxExtensions = types.Int64Null()
+	}
+	currentWindows := data.AccessWindows
+	data.AccessWindows = nil
+	for i, window := range policy.AccessWindows {
+		var currentWindow AccessWindowModel
+		if i < len(currentWindows) {
+			currentWindow = currentWindows[i]
+		}
+		data.AccessWindows = append(data.AccessWindows, accessWindowToModelConverter(window, currentWindow))
+	}
+	data.Id = types.StringValue(policy.Id)
+	data.State = types.StringValue(policy.State)
+}
+
+// preserveCase keeps the configured spelling of a case-insensitive value when
+// Crosswire returns it normalized, so "all" does not plan a change to "ALL".
+// Terraform requires planned values to match the configuration exactly, so
+// this cannot be done with a plan modifier.
+func preserveCase(current types.String, value string) types.String {
+	if !current.IsNull() && !current.IsUnknown() && strings.EqualFold(current.ValueString(), value) {
+		return current
+	}
+	return types.StringValue(value)
+}
+
+func approvalStageFromModelConverter(data ApprovalStageModel) ApprovalStage {
+	stage := ApprovalStage{
+		UserApprovers:        usersFromModelConverter(data.UserApprovers),
+		EntitlementApprovers: entitlementsFromModelConverter(data.EntitlementApprovers),
+		GroupApprovers:       stringsFromModelConverter(data.GroupApprovers),
+	}
+	if !data.SpecialApprover.IsNull() && !data.SpecialApprover.IsUnknown() {
+		stage.SpecialApprover = ToPointer(data.SpecialApprover.ValueString())
+	}
+	if !data.ApprovalBehavior.IsNull() && !data.ApprovalBehavior.IsUnknown() {
+		stage.ApprovalBehavior = ToPointer(data.ApprovalBehavior.ValueString())
+	}
+	if !data.MinApprovals.IsNull() && !data.MinApprovals.IsUnknown() {
+		stage.MinApprovals = ToPointer(data.MinApprovals.ValueInt64())
+	}
+	return stage
+}
+
+// approvalStageToModelConverter converts stage, keeping the case of the enums
+// configured in current.
+func approvalStageToModelConverter(stage ApprovalStage, current ApprovalStageModel) ApprovalStageModel {
+	data := ApprovalStageModel{
+		SpecialApprover:      types.StringNull(),
+		ApprovalBehavior:     types.StringNull(),
+		MinApprovals:         types.Int64Null(),
+		UserApprovers:        usersToModelConverter(stage.UserApprovers),
+		EntitlementApprovers: entitlementsToModelConverter(stage.EntitlementApprovers),
+		GroupApprovers:       stringsToModelConverter(stage.GroupApprovers),
+	}
+	if stage.SpecialApprover != nil {
+		data.SpecialApprover = preserveCase(current.SpecialApprover, *stage.SpecialApprover)
+	}
+	if stage.ApprovalBehavior != nil {
+		data.ApprovalBehavior = preserveCase(current.ApprovalBehavior, *stage.ApprovalBehavior)
+	}
+	if stage.MinApprovals != nil && *stage.MinApprovals > 0 {
+		data.MinApprovals = types.Int64Value(*stage.MinApprovals)
+	}
+	return data
+}
+
+// accessWindowToModelConverter converts window, keeping the case of the days
+// configured in current.
+func accessWindowToModelConverter(window AccessWindow, current AccessWindowModel) AccessWindowModel {
+	data := AccessWindowModel{
+		StartTime: types.StringValue(window.StartTime),
+		EndTime:   types.StringValue(window.EndTime),
+		TimeZone:  types.StringValue(window.TimeZone),
+	}
+	for _, day := range window.Days {
+		value := types.StringValue(day)
+		for _, configured := range current.Days {
+			if !configured.IsUnknown() && strings.EqualFold(configured.ValueString(), day) {
+				value = configured
+				break
+			}
+		}
+		data.Days = append(data.Days, value)
+	}
+	return data
+}
+
+func valueOrDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+func usersFromModelConverter(users []UserModel) []string {
+	var emails []string
+	for _, user := range users {
+		emails = append(emails, user.EmailAddress.ValueString())
+	}
+	return emails
+}
+
+func usersToModelConverter(emails []string) []UserModel {
+	var users []UserModel
+	for _, email := range emails {
+		users = append(users, UserModel{EmailAddress: types.StringValue(email)})
+	}
+	return users
+}
+
+func stringsFromModelConverter(values []types.String) []string {
+	var converted []string
+	for _, value := range values {
+		converted = append(converted, value.ValueString())
+	}
+	return converted
+}
+
+func stringsToModelConverter(values []string) []types.String {
+	var converted []types.String
+	for _, value := range values {
+		converted = append(converted, types.StringValue(value))
+	}
+	return converted
+}
+
+func entitlementsFromModelConverter(modelEntitlements []EntitlementModel) []Entitlement {
+	var entitlements []Entitlement
+	for _, entitlement := range modelEntitlements {
+		entitlements = append(entitlements, Entitlement{
+			Provider: entitlement.Provider.ValueString(),
+			Subject:  entitlement.Subject.ValueString(),
+			Object:   entitlement.Object.ValueString(),
+		})
+	}
+	return entitlements
+}
+
 func entitlementsToModelConverter(entitlements []Entitlement) []EntitlementModel {
 	var entitlementsModel []EntitlementModel
 	for _, entitlement := range entitlements {
@@ -421,27 +936,162 @@ func entitlementsToModelConverter(entitlements []Entitlement) []EntitlementModel
 	return entitlementsModel
 }
 
-func conditionToModelConverter(condition Condition) ConditionModel {
-	conditionModel := ConditionModel{
-		Quantifier:   types.StringValue(condition.Quantifier),
-		Entitlements: entitlementsToModelConverter(condition.Entitlements),
-	}
-	if len(condition.Subconditions) > 0 {
-		for _, subcondition := range condition.Subconditions {
-			conditionModel.Subconditions = append(conditionModel.Subconditions, conditionToModelConverter(subcondition))
-		}
+// conditionToModelConverter sets condition, or condition_json when the policy
+// was configured with it or its tree is too deep for the condition attribute.
+func conditionToModelConverter(condition Condition, data *PolicyResourceModel) {
+	if data.ConditionJSON.IsNull() && conditionDepth(condition) <= maxConditionDepth {
+		data.Condition = conditionToObjectValue(preserveConditionCase(data.Condition, condition), 0)
+		data.ConditionJSON = types.StringNull()
+		return
 	}
-	return conditionModel
+
+	data.Condition = types.ObjectNull(conditionObjectType(0).AttrTypes)
+	data.ConditionJSON = conditionJSONValue(data.ConditionJSON, condition)
 }
 
 func (p *PolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update is not yet supported", "Please contact customer support for additional information.")
+	var data PolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state PolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// revocation_behavior and timeouts are not stored in Crosswire, so
+	// changing only them does not need an API call.
+	if onlyTerraformAttributesChanged(data, state) {
+		data.Id = state.Id
+		data.State = state.State
+		data.LastUpdated = state.LastUpdated
+		tflog.Trace(ctx, "updated a resource without changing the policy")
+		resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	policy, err := policyFromModelConverter(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("condition_json"),
+			"Invalid condition_json",
+			"Could not decode the condition: "+err.Error(),
+		)
+		return
+	}
+	policy.Id = state.Id.ValueString()
+
+	updatedPolicy, err := p.client.updatePolicy(ctx, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating policy",
+			"Could not update policy "+policy.Id+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	policyToModelConverter(updatedPolicy, &data)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	tflog.Trace(ctx, "updated a resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// onlyTerraformAttributesChanged reports whether plan and state build the same
+// request body, so that updating Crosswire would not change the policy.
+func onlyTerraformAttributesChanged(plan, state PolicyResourceModel) bool {
+	planned, err := policyFromModelConverter(plan)
+	if err != nil {
+		return false
+	}
+	current, err := policyFromModelConverter(state)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(planned, current)
 }
 
 func (p *PolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	resp.Diagnostics.AddWarning("Delete is not yet supported", "Please contact customer support for additional information.")
+	var state PolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultPolicyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	behavior := "REVOKE"
+	if !state.RevocationBehavior.IsNull() && !state.RevocationBehavior.IsUnknown() {
+		behavior = strings.ToUpper(state.RevocationBehavior.ValueString())
+	}
+
+	if err := p.client.deletePolicy(ctx, state.Id.ValueString(), behavior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting policy",
+			"Could not delete policy "+state.Id.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource", map[string]any{"revocation_behavior": behavior})
 }
 
+// importNamePrefix marks an import ID as a policy name rather than an id,
+// e.g. `terraform import crosswire_policy.db "name:Prod DB Admin"`.
+const importNamePrefix = "name:"
+
 func (p *PolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	var policy *Policy
+	var err error
+	if strings.HasPrefix(req.ID, importNamePrefix) {
+		policy, err = p.client.getPolicyByName(ctx, strings.TrimPrefix(req.ID, importNamePrefix))
+	} else {
+		policy, err = p.client.getPolicy(ctx, req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing policy",
+			fmt.Sprintf("Could not find policy %q: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	// Populate the complete model, including attributes that only exist in
+	// Terraform, so the first plan after import is clean.
+	var data PolicyResourceModel
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyToModelConverter(policy, &data)
+	data.RevocationBehavior = types.StringValue("REVOKE")
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -1,18 +1,26 @@
 package crosswire
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccPolicyResource(t *testing.T) {
 	name := RandomStringGenerator(16)
 	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	policyPath := "/integrations/crosswire_terraform/policy"
+	var requests int
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -47,16 +55,979 @@ func TestAccPolicyResource(t *testing.T) {
 					resource.TestCheckResourceAttr(terraform_resource, "approval_behavior", "ANY"),
 					resource.TestCheckResourceAttr(terraform_resource, "special_approver", "NONE"),
 					resource.TestCheckResourceAttr(terraform_resource, "state", "ACTIVE"),
+					resource.TestCheckResourceAttr(terraform_resource, "revocation_behavior", "REVOKE"),
 					resource.TestCheckNoResourceAttr(terraform_resource, "ttl"),
 				),
 			},
 			// ImportState testing
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateId:           "name:" + name,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
 			// Update and Read testing
-			// Delete testing automatically occurs in TestCase
+			{
+				Config: testAccPolicyResourceConfigUpdated(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "owner.email_address", "user@company.com"),
+					resource.TestCheckResourceAttr(terraform_resource, "name", name+"-updated"),
+					resource.TestCheckResourceAttr(terraform_resource, "entitlements.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "entitlements.*", map[string]string{
+						"provider": "CROSSWIRE", "subject": "CREATE", "object": "ENTITLEMENT"}),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.quantifier", "ALL"),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.entitlements.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "condition.entitlements.*", map[string]string{
+						"provider": "CROSSWIRE", "subject": "ROLE", "object": "ADMIN"}),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "condition.entitlements.*", map[string]string{
+						"provider": "CROSSWIRE", "subject": "READ", "object": "POLICY"}),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.subconditions.#", "0"),
+					resource.TestCheckResourceAttr(terraform_resource, "user_approvers.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "user_approvers.*", map[string]string{
+						"email_address": "approver@company.com"}),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "user_approvers.*", map[string]string{
+						"email_address": "second.approver@company.com"}),
+					resource.TestCheckResourceAttr(terraform_resource, "entitlement_approvers.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "entitlement_approvers.*", map[string]string{
+						"provider": "CROSSWIRE", "subject": "APPROVE", "object": "POLICY"}),
+					resource.TestCheckResourceAttr(terraform_resource, "approval_behavior", "ALL"),
+					resource.TestCheckResourceAttr(terraform_resource, "special_approver", "NONE"),
+					resource.TestCheckResourceAttr(terraform_resource, "state", "ACTIVE"),
+					resource.TestCheckResourceAttr(terraform_resource, "ttl", "3600"),
+				),
+			},
+			// Enum values are case insensitive and keep their configured case
+			{
+				Config: strings.ReplaceAll(testAccPolicyResourceConfigUpdated(name), `"ALL"`, `"all"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "approval_behavior", "all"),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.quantifier", "all"),
+				),
+			},
+			// revocation_behavior only affects Terraform, and is sent on destroy
+			{
+				Config: testAccPolicyResourceConfig(name),
+				Check:  testAccCheckFakeRequest(&requests, "PUT "+policyPath, "", true),
+			},
+			{
+				Config: testAccPolicyResourceConfigRevocation(name, "EXPIRE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "revocation_behavior", "EXPIRE"),
+					testAccCheckFakeRequest(&requests, "PUT "+policyPath, "", false),
+				),
+			},
+			{
+				Config: `provider "crosswire" {}`,
+				Check:  testAccCheckFakeRequest(&requests, "DELETE "+policyPath, "revocationBehavior=EXPIRE", true),
+			},
+			{
+				Config: testAccPolicyResourceConfigRevocation(name, "archive"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "revocation_behavior", "archive"),
+					testAccCheckFakeRequest(&requests, "POST "+policyPath, "", true),
+				),
+			},
+			{
+				Config: `provider "crosswire" {}`,
+				Check:  testAccCheckFakeRequest(&requests, "DELETE "+policyPath, "revocationBehavior=ARCHIVE", true),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResource_UserValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			// Users are checked without strict_validation
+			{
+				Config: `
+resource "crosswire_policy" "users" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "user validation"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "ROLE"
+      object   = "ADMIN"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "aprover@company.com"
+    }
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Did you mean\s+approver@company.com\?`),
+			},
+			{
+				Config: `
+resource "crosswire_policy" "users" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "user validation"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "ROLE"
+      object   = "ADMIN"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  approval_stages = [
+    {
+      user_approvers = [
+        {
+          email_address = "former.employee@company.com"
+        }
+      ]
+    }
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Deactivated user`),
+			},
+			{
+				Config: `
+provider "crosswire" {
+  validate_users = false
+}
+
+resource "crosswire_policy" "users" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "user validation"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "ROLE"
+      object   = "ADMIN"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "aprover@company.com"
+    }
+  ]
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccPolicyResourceStrictValidation(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEntitlementDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPolicyResourceConfigStrict(name, "TYPO", "approver@company.com"),
+				ExpectError: regexp.MustCompile(`Unknown entitlement`),
+			},
+			// Entitlements declared in the same configuration count as known
+			{
+				Config: testAccPolicyResourceConfigStrict(name, name, "approver@company.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "condition.subconditions.0.entitlements.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResourceConditionJSON(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	data_source := fmt.Sprintf("data.crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPolicyResourceConfigCondition(name, testAccDeepConditionJSON+"\n"+testAccThreeLevelCondition),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config:      testAccPolicyResourceConfigCondition(name, strings.Replace(testAccDeepConditionJSON, `"ALL"`, `"SOME"`, 1)),
+				ExpectError: regexp.MustCompile(`quantifier must be one of ANY, ALL`),
+			},
+			{
+				Config:      testAccPolicyResourceConfigCondition(name, strings.Replace(testAccThreeLevelCondition, "threshold  = 2", "threshold  = 3", 1)),
+				ExpectError: regexp.MustCompile(`threshold is 3 but the condition only has 2`),
+			},
+			// Conditions deeper than the condition attribute allows
+			{
+				Config: testAccPolicyResourceConfigCondition(name, testAccDeepConditionJSON),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(terraform_resource, "condition.quantifier"),
+					resource.TestCheckResourceAttrSet(terraform_resource, "condition_json"),
+					resource.TestCheckResourceAttrPair(data_source, "condition_json", terraform_resource, "condition_json"),
+					resource.TestCheckNoResourceAttr(data_source, "condition.quantifier"),
+				),
+			},
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// The condition attribute round-trips every level it supports
+			{
+				Config: testAccPolicyResourceConfigCondition(name, testAccThreeLevelCondition),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(terraform_resource, "condition_json"),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.threshold", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.subconditions.0.subconditions.0.quantifier", "NONE"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "condition.subconditions.0.threshold"),
+					resource.TestCheckResourceAttr(data_source, "condition.subconditions.0.subconditions.0.entitlements.#", "1"),
+					resource.TestCheckResourceAttrSet(data_source, "condition_json"),
+				),
+			},
+			// Quantifiers keep their configured case at every level
+			{
+				Config: testAccPolicyResourceConfigCondition(name, strings.NewReplacer(`"ALL"`, `"all"`, `"NONE"`, `"none"`).Replace(testAccThreeLevelCondition)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "condition.quantifier", "AT_LEAST"),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.subconditions.0.quantifier", "all"),
+					resource.TestCheckResourceAttr(terraform_resource, "condition.subconditions.0.subconditions.0.quantifier", "none"),
+					resource.TestCheckResourceAttr(data_source, "condition.subconditions.0.subconditions.0.quantifier", "NONE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResourceApprovalStages(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	data_source := fmt.Sprintf("data.crosswire_policy.%s", name)
+	stages := `
+  approval_stages = [
+    {
+      special_approver = "MANAGER"
+    },
+    {
+      user_approvers = [
+        {
+          email_address = "approver@company.com"
+        },
+        {
+          email_address = "second.approver@company.com"
+        }
+      ]
+      min_approvals = 2
+    }
+  ]`
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyResourceConfigApprovers(name, stages+`
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: testAccPolicyResourceConfigApprovers(name, stages),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "approval_stages.#", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "approval_stages.0.special_approver", "MANAGER"),
+					resource.TestCheckResourceAttr(terraform_resource, "approval_stages.1.user_approvers.#", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "approval_stages.1.min_approvals", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "special_approver", "NONE"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "user_approvers"),
+					resource.TestCheckResourceAttr(data_source, "approval_stages.#", "2"),
+					resource.TestCheckResourceAttr(data_source, "approval_stages.0.special_approver", "MANAGER"),
+				),
+			},
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// The flat attributes are a single-stage shorthand
+			{
+				Config: testAccPolicyResourceConfigApprovers(name, `
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(terraform_resource, "approval_stages.#"),
+					resource.TestCheckResourceAttr(terraform_resource, "user_approvers.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResource_MinApprovals(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    },
+    {
+      email_address = "second.approver@company.com"
+    }
+  ]
+  approval_behavior = "ALL"
+  min_approvals     = 2
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Conflicting approval settings`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  min_approvals = 2
+}
+`, name),
+				ExpectError: regexp.MustCompile(`min_approvals is 2 but only 1 user_approvers are set`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    },
+    {
+      email_address = "second.approver@company.com"
+    }
+  ]
+  approval_behavior = "ANY"
+  min_approvals     = 2
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "approval_behavior", "ANY"),
+					resource.TestCheckResourceAttr(terraform_resource, "min_approvals", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "user_approvers.#", "2"),
+				),
+			},
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Removing min_approvals falls back to a single approval
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    },
+    {
+      email_address = "second.approver@company.com"
+    }
+  ]
+  approval_behavior = "ANY"
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(terraform_resource, "min_approvals"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResource_Justification(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  ticket_pattern = "CHG-[0-9+"
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Invalid regular expression`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  require_justification    = true
+  justification_min_length = 20
+  ticket_pattern           = "^CHG-[0-9]+$"
+  ticket_system            = "SERVICENOW"
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "require_justification", "true"),
+					resource.TestCheckResourceAttr(terraform_resource, "justification_min_length", "20"),
+					resource.TestCheckResourceAttr(terraform_resource, "ticket_pattern", "^CHG-[0-9]+$"),
+					resource.TestCheckResourceAttr(terraform_resource, "ticket_system", "SERVICENOW"),
+				),
+			},
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Removing the requirements clears them
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "require_justification", "false"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "justification_min_length"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "ticket_pattern"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "ticket_system"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResource_AccessWindows(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  access_windows = [
+    {
+      days       = ["MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"]
+      start_time = "09:00"
+      end_time   = "17:30"
+      time_zone  = "Europe/Londres"
+    }
+  ]
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Invalid time zone`),
+			},
+			// A Sunday night window runs into Monday morning
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  access_windows = [
+    {
+      days       = ["SUNDAY"]
+      start_time = "22:00"
+      end_time   = "02:00"
+      time_zone  = "Europe/London"
+    },
+    {
+      days       = ["MONDAY"]
+      start_time = "01:00"
+      end_time   = "03:00"
+      time_zone  = "Europe/London"
+    }
+  ]
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Overlapping access windows`),
+			},
+			// Days keep their configured case
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  access_windows = [
+    {
+      days       = ["monday", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"]
+      start_time = "09:00"
+      end_time   = "17:30"
+      time_zone  = "Europe/London"
+    },
+    {
+      days       = ["SUNDAY"]
+      start_time = "22:00"
+      end_time   = "02:00"
+      time_zone  = "Europe/London"
+    }
+  ]
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "access_windows.#", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "access_windows.0.days.#", "5"),
+					resource.TestCheckTypeSetElemAttr(terraform_resource, "access_windows.0.days.*", "monday"),
+					resource.TestCheckResourceAttr(terraform_resource, "access_windows.0.end_time", "17:30"),
+					resource.TestCheckTypeSetElemAttr(terraform_resource, "access_windows.1.days.*", "SUNDAY"),
+					resource.TestCheckResourceAttr(terraform_resource, "access_windows.1.start_time", "22:00"),
+					resource.TestCheckResourceAttr(terraform_resource, "access_windows.1.end_time", "02:00"),
+					resource.TestCheckResourceAttr(terraform_resource, "access_windows.1.time_zone", "Europe/London"),
+				),
+			},
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "access_windows.0.days"},
+			},
+		},
+	})
+}
+
+func TestAccPolicyResource_TTL(t *testing.T) {
+	name := RandomStringGenerator(16)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  ttl = 3600
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "ttl", "3600"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "max_ttl"),
+				),
+			},
+			// Imported policies use ttl
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  max_ttl = "4 hours"
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Invalid duration`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  default_ttl = "8h"
+  max_ttl     = "4h"
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Invalid default_ttl`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  ttl     = 3600
+  max_ttl = "1h"
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  max_extensions = 2
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Extensions not allowed`),
+			},
+			// Durations keep their configured spelling
+			{
+				Config: fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    }
+  ]
+  default_ttl     = "1h"
+  max_ttl         = "240m"
+  allow_extension = true
+  max_extensions  = 2
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(terraform_resource, "default_ttl", "1h"),
+					resource.TestCheckResourceAttr(terraform_resource, "max_ttl", "240m"),
+					resource.TestCheckNoResourceAttr(terraform_resource, "ttl"),
+					resource.TestCheckResourceAttr(terraform_resource, "allow_extension", "true"),
+					resource.TestCheckResourceAttr(terraform_resource, "max_extensions", "2"),
+				),
+			},
+			// Importing after max_ttl switches to ttl
+			{
+				ResourceName:            terraform_resource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "ttl", "max_ttl"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["ttl"] != "14400" || states[0].Attributes["max_ttl"] != "" {
+						return fmt.Errorf("expected the imported policy to set ttl = 14400 and no max_ttl, got %v", states)
+					}
+					return nil
+				},
+			},
 		},
 	})
 }
 
+func testAccCheckPolicyDestroy(s *terraform.State) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "crosswire_policy" {
+			continue
+		}
+
+		_, err := client.getPolicy(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("policy %s still exists", rs.Primary.ID)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func testAccPolicyResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "crosswire_policy" "%[1]s" {
@@ -124,3 +1095,225 @@ resource "crosswire_policy" "%[1]s" {
 }
 `, name)
 }
+
+func testAccPolicyResourceConfigRevocation(name, revocationBehavior string) string {
+	return strings.Replace(testAccPolicyResourceConfig(name), `approval_behavior = "ANY"`, fmt.Sprintf(`approval_behavior   = "ANY"
+  revocation_behavior = %q`, revocationBehavior), 1)
+}
+
+func testAccPolicyResourceConfigUpdated(name string) string {
+	return fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s-updated"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ALL"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ADMIN"
+      },
+      {
+        provider = "CROSSWIRE"
+        subject  = "READ"
+        object   = "POLICY"
+      }
+    ]
+  }
+  user_approvers = [
+    {
+      email_address = "approver@company.com"
+    },
+    {
+      email_address = "second.approver@company.com"
+    }
+  ]
+  entitlement_approvers = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "APPROVE"
+      object   = "POLICY"
+    }
+  ]
+  approval_behavior = "ALL"
+  ttl               = 3600
+}
+`, name)
+}
+
+func testAccPolicyResourceConfigStrict(name, subconditionObject, approver string) string {
+	return fmt.Sprintf(`
+provider "crosswire" {
+  strict_validation = true
+}
+
+resource "crosswire_entitlement" "%[1]s_grant" {
+  provider_name = "CROSSWIRE"
+  subject       = "GRANT"
+  object        = "%[1]s"
+}
+
+resource "crosswire_entitlement" "%[1]s_eligible" {
+  provider_name = "CROSSWIRE"
+  subject       = "ELIGIBLE"
+  object        = "%[1]s"
+}
+
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = crosswire_entitlement.%[1]s_grant.provider_name
+      subject  = crosswire_entitlement.%[1]s_grant.subject
+      object   = crosswire_entitlement.%[1]s_grant.object
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    subconditions = [
+      {
+        quantifier = "ALL"
+        entitlements = [
+          {
+            provider = crosswire_entitlement.%[1]s_eligible.provider_name
+            subject  = crosswire_entitlement.%[1]s_eligible.subject
+            object   = "%[2]s"
+          }
+        ]
+      }
+    ]
+  }
+  user_approvers = [
+    {
+      email_address = "%[3]s"
+    }
+  ]
+}
+`, name, subconditionObject, approver)
+}
+
+// testAccDeepConditionJSON nests five levels of conditions, two more than the
+// condition attribute supports.
+const testAccDeepConditionJSON = `
+  condition_json = jsonencode({
+    quantifier = "ALL"
+    subconditions = [{
+      quantifier = "ANY"
+      subconditions = [{
+        quantifier = "ALL"
+        subconditions = [{
+          quantifier = "ANY"
+          subconditions = [{
+            quantifier = "AT_LEAST"
+            threshold  = 1
+            entitlements = [
+              {
+                provider = "CROSSWIRE"
+                subject  = "READ"
+                object   = "POLICY"
+              },
+              {
+                provider = "CROSSWIRE"
+                subject  = "ROLE"
+                object   = "ADMIN"
+              }
+            ]
+          }]
+        }]
+      }]
+    }]
+  })`
+
+// testAccThreeLevelCondition grants users holding CROSSWIRE READ POLICY who are
+// not CROSSWIRE ROLE ADMIN.
+const testAccThreeLevelCondition = `
+  condition = {
+    quantifier = "AT_LEAST"
+    threshold  = 2
+    entitlements = [{
+      provider = "CROSSWIRE"
+      subject  = "READ"
+      object   = "POLICY"
+    }]
+    subconditions = [{
+      quantifier = "ALL"
+      subconditions = [{
+        quantifier = "NONE"
+        entitlements = [{
+          provider = "CROSSWIRE"
+          subject  = "ROLE"
+          object   = "ADMIN"
+        }]
+      }]
+    }]
+  }`
+
+func testAccPolicyResourceConfigCondition(name, condition string) string {
+	return fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+%[2]s
+  special_approver = "SELF"
+}
+
+data "crosswire_policy" "%[1]s" {
+  id = crosswire_policy.%[1]s.id
+}
+`, name, condition)
+}
+
+func testAccPolicyResourceConfigApprovers(name, approvers string) string {
+	return fmt.Sprintf(`
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ADMIN"
+      }
+    ]
+  }
+%[2]s
+}
+
+data "crosswire_policy" "%[1]s" {
+  id = crosswire_policy.%[1]s.id
+}
+`, name, approvers)
+}
@@ -0,0 +1,511 @@
+package crosswire
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// minPolicyTTL and maxPolicyTTL bound ttl, default_ttl and max_ttl, in
+	// seconds. Omit them for grants that do not expire.
+	minPolicyTTL = 60
+	maxPolicyTTL = 365 * 24 * 60 * 60
+)
+
+// policyConfig holds the crosswire_policy attributes inspected by the
+// validation rules below. Any of them may be null or unknown.
+type policyConfig struct {
+	approverConfig
+
+	TTL                    types.Int64
+	DefaultTTL             DurationValue
+	MaxTTL                 DurationValue
+	AllowExtension         types.Bool
+	MaxExtensions          types.Int64
+	AccessWindows          types.List
+	Entitlements           types.Set
+	Condition              types.Object
+	ApprovalStages         types.List
+	RequireJustification   types.Bool
+	JustificationMinLength types.Int64
+}
+
+// approverConfig holds the approver attributes of the policy itself, its
+// single-stage shorthand, or of one of its approval_stages at Path.
+type approverConfig struct {
+	Path path.Path
+
+	SpecialApprover      types.String
+	ApprovalBehavior     types.String
+	MinApprovals         types.Int64
+	UserApprovers        types.Set
+	EntitlementApprovers types.Set
+	GroupApprovers       types.Set
+}
+
+// approverConfigs returns the approvers to validate: each of approval_stages
+// when it is set, or else the top-level shorthand.
+func (config policyConfig) approverConfigs() []approverConfig {
+	if config.ApprovalStages.IsNull() {
+		return []approverConfig{config.approverConfig}
+	}
+	return approvalStageConfigs(config.ApprovalStages)
+}
+
+// approvalStageConfigs returns the known elements of approval_stages.
+func approvalStageConfigs(list types.List) []approverConfig {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var stages []approverConfig
+	for i, element := range list.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		attributes := object.Attributes()
+		stage := approverConfig{Path: path.Root("approval_stages").AtListIndex(i)}
+		stage.SpecialApprover, _ = attributes["special_approver"].(types.String)
+		stage.ApprovalBehavior, _ = attributes["approval_behavior"].(types.String)
+		stage.MinApprovals, _ = attributes["min_approvals"].(types.Int64)
+		stage.UserApprovers, _ = attributes["user_approvers"].(types.Set)
+		stage.EntitlementApprovers, _ = attributes["entitlement_approvers"].(types.Set)
+		stage.GroupApprovers, _ = attributes["group_approvers"].(types.Set)
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// validatePolicyConfig runs every validation rule against config.
+func validatePolicyConfig(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(validatePolicyApprovers(config)...)
+	diags.Append(validatePolicyMinApprovals(config)...)
+	diags.Append(validatePolicyTTL(config)...)
+	diags.Append(validatePolicyExtensions(config)...)
+	diags.Append(validatePolicyAccessWindows(config)...)
+	diags.Append(validatePolicyJustification(config)...)
+	diags.Append(validatePolicyEntitlements(config)...)
+	diags.Append(validateConditionThresholds(config.Condition, path.Root("condition"))...)
+	return diags
+}
+
+// validatePolicyApprovers requires approvers when special_approver is NONE
+// and forbids them otherwise, as the special approver replaces them.
+func validatePolicyApprovers(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, approvers := range config.approverConfigs() {
+		diags.Append(validateApprovers(approvers)...)
+	}
+	return diags
+}
+
+func validateApprovers(config approverConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.SpecialApprover.IsUnknown() {
+		return diags
+	}
+
+	// special_approver defaults to NONE.
+	specialApprover := "NONE"
+	if !config.SpecialApprover.IsNull() {
+		specialApprover = strings.ToUpper(config.SpecialApprover.ValueString())
+	}
+
+	approvers := map[string]types.Set{
+		"user_approvers":        config.UserApprovers,
+		"entitlement_approvers": config.EntitlementApprovers,
+		"group_approvers":       config.GroupApprovers,
+	}
+
+	if specialApprover != "NONE" {
+		for _, name := range []string{"user_approvers", "entitlement_approvers", "group_approvers"} {
+			if set := approvers[name]; !set.IsUnknown() && len(set.Elements()) > 0 {
+				diags.AddAttributeError(
+					config.Path.AtName(name),
+					"Conflicting approvers",
+					fmt.Sprintf("%s cannot be set when special_approver is %s, as %s policies do not use approvers. Remove %s or set special_approver to NONE.",
+						name, specialApprover, specialApprover, name),
+				)
+			}
+		}
+		return diags
+	}
+
+	for _, set := range approvers {
+		if set.IsUnknown() || len(set.Elements()) > 0 {
+			return diags
+		}
+	}
+	if !config.Path.Equal(path.Empty()) {
+		diags.AddAttributeError(
+			config.Path,
+			"No approvers selected",
+			"At least one approver needs to be set to approve requests in this stage",
+		)
+		return diags
+	}
+	diags.AddError(
+		"No approvers selected",
+		"At least one approver needs to be set to approve policy requests",
+	)
+	return diags
+}
+
+// validatePolicyMinApprovals requires min_approvals to be reachable. Only
+// user_approvers are counted, as entitlement and group approvers expand to
+// users when a request is made.
+func validatePolicyMinApprovals(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, approvers := range config.approverConfigs() {
+		diags.Append(validateMinApprovals(approvers)...)
+	}
+	return diags
+}
+
+func validateMinApprovals(config approverConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.MinApprovals.IsNull() || config.MinApprovals.IsUnknown() {
+		return diags
+	}
+
+	minApprovals := config.MinApprovals.ValueInt64()
+	if minApprovals < 1 {
+		diags.AddAttributeError(
+			config.Path.AtName("min_approvals"),
+			"Invalid min_approvals",
+			fmt.Sprintf("min_approvals must be at least 1, got %d.", minApprovals),
+		)
+		return diags
+	}
+
+	if strings.EqualFold(config.ApprovalBehavior.ValueString(), "ALL") {
+		diags.AddAttributeError(
+			config.Path.AtName("min_approvals"),
+			"Conflicting approval settings",
+			"min_approvals cannot be set when approval_behavior is ALL, which already requires every approver. Remove min_approvals or set approval_behavior to ANY.",
+		)
+		return diags
+	}
+
+	if !config.SpecialApprover.IsNull() && !config.SpecialApprover.IsUnknown() && !strings.EqualFold(config.SpecialApprover.ValueString(), "NONE") {
+		diags.AddAttributeError(
+			config.Path.AtName("min_approvals"),
+			"Conflicting approval settings",
+			fmt.Sprintf("min_approvals cannot be set when special_approver is %s, as %s policies do not use approvers.",
+				config.SpecialApprover.ValueString(), config.SpecialApprover.ValueString()),
+		)
+		return diags
+	}
+
+	for _, set := range []types.Set{config.EntitlementApprovers, config.GroupApprovers} {
+		if set.IsUnknown() || len(set.Elements()) > 0 {
+			return diags
+		}
+	}
+	if config.UserApprovers.IsUnknown() {
+		return diags
+	}
+	if users := len(config.UserApprovers.Elements()); minApprovals > int64(users) {
+		diags.AddAttributeError(
+			config.Path.AtName("min_approvals"),
+			"Invalid min_approvals",
+			fmt.Sprintf("min_approvals is %d but only %d user_approvers are set, so requests could never be approved.", minApprovals, users),
+		)
+	}
+	return diags
+}
+
+// validatePolicyTTL bounds ttl, default_ttl and max_ttl, rejects them for
+// AUTO policies, and requires default_ttl to fit within the maximum. A policy
+// is AUTO when it or any of its approval stages has an AUTO special_approver.
+func validatePolicyTTL(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	auto := strings.EqualFold(config.SpecialApprover.ValueString(), "AUTO")
+	for _, stage := range approvalStageConfigs(config.ApprovalStages) {
+		auto = auto || strings.EqualFold(stage.SpecialApprover.ValueString(), "AUTO")
+	}
+
+	if !config.TTL.IsNull() && !config.TTL.IsUnknown() {
+		if auto {
+			diags.Append(autoPolicyTTLError(path.Root("ttl")))
+		} else if ttl := config.TTL.ValueInt64(); ttl < minPolicyTTL || ttl > maxPolicyTTL {
+			diags.AddAttributeError(
+				path.Root("ttl"),
+				"Invalid TTL",
+				fmt.Sprintf("ttl must be between %d and %d seconds, got %d. Omit ttl for access that does not expire.", minPolicyTTL, maxPolicyTTL, ttl),
+			)
+		}
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value DurationValue
+	}{{"default_ttl", config.DefaultTTL}, {"max_ttl", config.MaxTTL}} {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+		if auto {
+			diags.Append(autoPolicyTTLError(path.Root(attribute.name)))
+			continue
+		}
+		// Invalid durations are reported by DurationType.
+		if seconds, ok := attribute.value.Seconds(); ok && (seconds < minPolicyTTL || seconds > maxPolicyTTL) {
+			diags.AddAttributeError(
+				path.Root(attribute.name),
+				"Invalid TTL",
+				fmt.Sprintf("%s must be between %s and %s, got %s. Omit %[1]s for access that does not expire.", attribute.name, formatDuration(minPolicyTTL), formatDuration(maxPolicyTTL), attribute.value.ValueString()),
+			)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	defaultTTL, ok := config.DefaultTTL.Seconds()
+	if !ok {
+		return diags
+	}
+	maxTTL, ok := config.MaxTTL.Seconds()
+	if !ok && !config.TTL.IsNull() && !config.TTL.IsUnknown() {
+		maxTTL, ok = config.TTL.ValueInt64(), true
+	}
+	if ok && defaultTTL > maxTTL {
+		diags.AddAttributeError(
+			path.Root("default_ttl"),
+			"Invalid default_ttl",
+			fmt.Sprintf("default_ttl of %s is longer than the maximum of %s, so requests without a duration could never be granted.", config.DefaultTTL.ValueString(), formatDuration(maxTTL)),
+		)
+	}
+	return diags
+}
+
+func autoPolicyTTLError(p path.Path) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		p,
+		"Auto policies cannot have a TTL",
+		"Because users are granted access automatically, TTL is redundant as it would continue to be regranted until the user no longer qualifies, at which time, the user would lose access immediately regardless of time remaining.",
+	)
+}
+
+// validatePolicyExtensions rejects max_extensions unless extensions are
+// allowed, and extensions of grants that never expire.
+func validatePolicyExtensions(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.AllowExtension.IsUnknown() {
+		return diags
+	}
+
+	if !config.AllowExtension.ValueBool() {
+		if !config.MaxExtensions.IsNull() && !config.MaxExtensions.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root("max_extensions"),
+				"Extensions not allowed",
+				"max_extensions only applies when allow_extension is true. Set allow_extension = true or remove max_extensions.",
+			)
+		}
+		return diags
+	}
+
+	if config.TTL.IsNull() && config.DefaultTTL.IsNull() && config.MaxTTL.IsNull() {
+		diags.AddAttributeError(
+			path.Root("allow_extension"),
+			"Extensions require a TTL",
+			"Access that does not expire cannot be extended. Set max_ttl or default_ttl, or remove allow_extension.",
+		)
+	}
+	return diags
+}
+
+// validatePolicyAccessWindows rejects access windows that open and close at
+// the same time, and windows overlapping another one in the same time zone,
+// which usually means one of them has a mistake.
+func validatePolicyAccessWindows(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var checked []accessWindowConfig
+	for _, window := range accessWindowConfigs(config.AccessWindows) {
+		if window.StartTime == window.EndTime {
+			diags.AddAttributeError(
+				window.Path.AtName("end_time"),
+				"Invalid access window",
+				fmt.Sprintf("%s opens and closes at %s. Use start_time = \"00:00\" and end_time = \"24:00\" for a whole day.", window.Path, window.EndTime),
+			)
+			continue
+		}
+
+		for _, earlier := range checked {
+			if day, ok := accessWindowsOverlap(earlier.AccessWindow, window.AccessWindow); ok {
+				diags.AddAttributeError(
+					window.Path,
+					"Overlapping access windows",
+					fmt.Sprintf("%s overlaps %s on %s in %s. Merge them into a single window or change their days or times.", window.Path, earlier.Path, day, window.TimeZone),
+				)
+				break
+			}
+		}
+		checked = append(checked, window)
+	}
+	return diags
+}
+
+// validatePolicyJustification rejects justification_min_length unless
+// justifications are required, as it would have no effect.
+func validatePolicyJustification(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.JustificationMinLength.IsNull() || config.JustificationMinLength.IsUnknown() || config.RequireJustification.IsUnknown() {
+		return diags
+	}
+
+	if !config.RequireJustification.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("justification_min_length"),
+			"Justification not required",
+			"justification_min_length only applies when require_justification is true. Set require_justification = true or remove justification_min_length.",
+		)
+	}
+	return diags
+}
+
+// validatePolicyEntitlements requires at least one granted entitlement and
+// rejects entries that only differ by case, which Crosswire treats as
+// duplicates.
+func validatePolicyEntitlements(config policyConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !config.Entitlements.IsUnknown() && len(config.Entitlements.Elements()) == 0 {
+		diags.AddAttributeError(
+			path.Root("entitlements"),
+			"No entitlements selected",
+			"At least one entitlement needs to be set for the policy to function",
+		)
+	}
+
+	diags.Append(validateUniqueEntitlements(config.Entitlements, path.Root("entitlements"))...)
+	diags.Append(validateUniqueConditionEntitlements(config.Condition, path.Root("condition"))...)
+	for _, approvers := range config.approverConfigs() {
+		diags.Append(validateUniqueEntitlements(approvers.EntitlementApprovers, approvers.Path.AtName("entitlement_approvers"))...)
+		diags.Append(validateUniqueUsers(approvers.UserApprovers, approvers.Path.AtName("user_approvers"))...)
+	}
+	return diags
+}
+
+func validateUniqueEntitlements(set types.Set, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if set.IsNull() || set.IsUnknown() {
+		return diags
+	}
+
+	seen := map[Entitlement]bool{}
+	for _, element := range set.Elements() {
+		entitlement, ok := knownEntitlement(element)
+		if !ok {
+			continue
+		}
+		key := Entitlement{
+			Provider: strings.ToUpper(entitlement.Provider),
+			Subject:  strings.ToUpper(entitlement.Subject),
+			Object:   strings.ToUpper(entitlement.Object),
+		}
+		if seen[key] {
+			diags.AddAttributeError(
+				p.AtSetValue(element),
+				"Duplicate entitlement",
+				fmt.Sprintf("Entitlement provider=%q subject=%q object=%q is listed more than once, ignoring case.",
+					entitlement.Provider, entitlement.Subject, entitlement.Object),
+			)
+		}
+		seen[key] = true
+	}
+	return diags
+}
+
+func validateUniqueConditionEntitlements(condition types.Object, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if condition.IsNull() || condition.IsUnknown() {
+		return diags
+	}
+
+	attributes := condition.Attributes()
+	if entitlements, ok := attributes["entitlements"].(types.Set); ok {
+		diags.Append(validateUniqueEntitlements(entitlements, p.AtName("entitlements"))...)
+	}
+
+	subconditions, ok := attributes["subconditions"].(types.Set)
+	if !ok || subconditions.IsNull() || subconditions.IsUnknown() {
+		return diags
+	}
+	for _, element := range subconditions.Elements() {
+		if subcondition, ok := element.(types.Object); ok {
+			diags.Append(validateUniqueConditionEntitlements(subcondition, p.AtName("subconditions").AtSetValue(element))...)
+		}
+	}
+	return diags
+}
+
+// validateConditionThresholds checks the threshold of condition and of every
+// nested subcondition against its quantifier and number of entitlements and
+// subconditions.
+func validateConditionThresholds(condition types.Object, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if condition.IsNull() || condition.IsUnknown() {
+		return diags
+	}
+
+	attributes := condition.Attributes()
+	quantifier, _ := attributes["quantifier"].(types.String)
+	threshold, _ := attributes["threshold"].(types.Int64)
+	entitlements, _ := attributes["entitlements"].(types.Set)
+	subconditions, _ := attributes["subconditions"].(types.Set)
+
+	if !quantifier.IsUnknown() && !threshold.IsUnknown() {
+		children := 0
+		for _, set := range []types.Set{entitlements, subconditions} {
+			if set.IsUnknown() {
+				children = -1
+				break
+			}
+			children += len(set.Elements())
+		}
+
+		var value *int64
+		if !threshold.IsNull() {
+			value = ToPointer(threshold.ValueInt64())
+		}
+		if problem := thresholdProblem(quantifier.ValueString(), value, children); problem != "" {
+			diags.AddAttributeError(p.AtName("threshold"), "Invalid threshold", problem+".")
+		}
+	}
+
+	if subconditions.IsNull() || subconditions.IsUnknown() {
+		return diags
+	}
+	for _, element := range subconditions.Elements() {
+		if subcondition, ok := element.(types.Object); ok {
+			diags.Append(validateConditionThresholds(subcondition, p.AtName("subconditions").AtSetValue(element))...)
+		}
+	}
+	return diags
+}
+
+func validateUniqueUsers(set types.Set, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	seen := map[string]bool{}
+	for _, reference := range userSetReferences(set, p) {
+		key := strings.ToLower(reference.email)
+		if seen[key] {
+			diags.AddAttributeError(
+				reference.path,
+				"Duplicate user",
+				fmt.Sprintf("User %q is listed more than once, ignoring case.", reference.email),
+			)
+		}
+		seen[key] = true
+	}
+	return diags
+}
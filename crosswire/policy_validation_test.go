@@ -0,0 +1,763 @@
+package crosswire
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testUserType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"email_address": types.StringType,
+}}
+
+func testUsers(emails ...string) types.Set {
+	var elements []attr.Value
+	for _, email := range emails {
+		elements = append(elements, types.ObjectValueMust(testUserType.AttrTypes, map[string]attr.Value{
+			"email_address": types.StringValue(email),
+		}))
+	}
+	return types.SetValueMust(testUserType, elements)
+}
+
+func testEntitlements(tuples ...[3]string) types.Set {
+	var elements []attr.Value
+	for _, tuple := range tuples {
+		elements = append(elements, testEntitlementValue(tuple[0], tuple[1], types.StringValue(tuple[2])))
+	}
+	return types.SetValueMust(testEntitlementType, elements)
+}
+
+func testCondition(entitlements types.Set) types.Object {
+	return types.ObjectValueMust(map[string]attr.Type{
+		"quantifier":   types.StringType,
+		"entitlements": types.SetType{ElemType: testEntitlementType},
+	}, map[string]attr.Value{
+		"quantifier":   types.StringValue("ANY"),
+		"entitlements": entitlements,
+	})
+}
+
+var testApprovalStageType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"special_approver":      types.StringType,
+	"approval_behavior":     types.StringType,
+	"min_approvals":         types.Int64Type,
+	"user_approvers":        types.SetType{ElemType: testUserType},
+	"entitlement_approvers": types.SetType{ElemType: testEntitlementType},
+	"group_approvers":       types.SetType{ElemType: types.StringType},
+}}
+
+func testApprovalStage(specialApprover string, minApprovals types.Int64, users types.Set) attr.Value {
+	special := types.StringNull()
+	if specialApprover != "" {
+		special = types.StringValue(specialApprover)
+	}
+	return types.ObjectValueMust(testApprovalStageType.AttrTypes, map[string]attr.Value{
+		"special_approver":      special,
+		"approval_behavior":     types.StringNull(),
+		"min_approvals":         minApprovals,
+		"user_approvers":        users,
+		"entitlement_approvers": types.SetNull(testEntitlementType),
+		"group_approvers":       types.SetNull(types.StringType),
+	})
+}
+
+func testApprovalStages(stages ...attr.Value) types.List {
+	return types.ListValueMust(testApprovalStageType, stages)
+}
+
+var testAccessWindowType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"days":       types.SetType{ElemType: types.StringType},
+	"start_time": types.StringType,
+	"end_time":   types.StringType,
+	"time_zone":  types.StringType,
+}}
+
+func testAccessWindow(days []string, startTime, endTime string, timeZone types.String) attr.Value {
+	var elements []attr.Value
+	for _, day := range days {
+		elements = append(elements, types.StringValue(day))
+	}
+	return types.ObjectValueMust(testAccessWindowType.AttrTypes, map[string]attr.Value{
+		"days":       types.SetValueMust(types.StringType, elements),
+		"start_time": types.StringValue(startTime),
+		"end_time":   types.StringValue(endTime),
+		"time_zone":  timeZone,
+	})
+}
+
+func testThresholdCondition(quantifier string, threshold types.Int64, entitlements types.Set) types.Object {
+	return types.ObjectValueMust(map[string]attr.Type{
+		"quantifier":   types.StringType,
+		"threshold":    types.Int64Type,
+		"entitlements": types.SetType{ElemType: testEntitlementType},
+	}, map[string]attr.Value{
+		"quantifier":   types.StringValue(quantifier),
+		"threshold":    threshold,
+		"entitlements": entitlements,
+	})
+}
+
+// testValidPolicyConfig returns a config passing every rule, for test cases to
+// modify.
+func testValidPolicyConfig() policyConfig {
+	return policyConfig{
+		approverConfig: approverConfig{
+			SpecialApprover:      types.StringNull(),
+			ApprovalBehavior:     types.StringNull(),
+			MinApprovals:         types.Int64Null(),
+			UserApprovers:        testUsers("approver@company.com"),
+			EntitlementApprovers: types.SetNull(testEntitlementType),
+			GroupApprovers:       types.SetNull(types.StringType),
+		},
+		TTL:                    types.Int64Null(),
+		DefaultTTL:             DurationNull(),
+		MaxTTL:                 DurationNull(),
+		AllowExtension:         types.BoolNull(),
+		MaxExtensions:          types.Int64Null(),
+		AccessWindows:          types.ListNull(testAccessWindowType),
+		Entitlements:           testEntitlements([3]string{"AWS", "ROLE", "admin"}),
+		Condition:              testCondition(testEntitlements([3]string{"OKTA", "GROUP", "eng"})),
+		ApprovalStages:         types.ListNull(testApprovalStageType),
+		RequireJustification:   types.BoolNull(),
+		JustificationMinLength: types.Int64Null(),
+	}
+}
+
+// diagnosticSummaries lists the summaries of diags, for compact comparisons.
+func diagnosticSummaries(diags diag.Diagnostics) string {
+	var summaries []string
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary())
+	}
+	return strings.Join(summaries, ",")
+}
+
+func TestValidatePolicyApprovers(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*policyConfig)
+		want   string
+	}{
+		{
+			name:   "user approvers",
+			modify: func(c *policyConfig) {},
+		},
+		{
+			name: "group approvers only",
+			modify: func(c *policyConfig) {
+				c.UserApprovers = types.SetNull(testUserType)
+				c.GroupApprovers = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("group-1")})
+			},
+		},
+		{
+			name: "no approvers with default special approver",
+			modify: func(c *policyConfig) {
+				c.UserApprovers = types.SetNull(testUserType)
+			},
+			want: "No approvers selected",
+		},
+		{
+			name: "empty approvers with NONE",
+			modify: func(c *policyConfig) {
+				c.SpecialApprover = types.StringValue("none")
+				c.UserApprovers = testUsers()
+			},
+			want: "No approvers selected",
+		},
+		{
+			name: "unknown approvers",
+			modify: func(c *policyConfig) {
+				c.UserApprovers = types.SetUnknown(testUserType)
+			},
+		},
+		{
+			name: "special approver without approvers",
+			modify: func(c *policyConfig) {
+				c.SpecialApprover = types.StringValue("MANAGER")
+				c.UserApprovers = types.SetNull(testUserType)
+			},
+		},
+		{
+			name: "special approver with approvers",
+			modify: func(c *policyConfig) {
+				c.SpecialApprover = types.StringValue("self")
+				c.EntitlementApprovers = testEntitlements([3]string{"OKTA", "GROUP", "approvers"})
+			},
+			want: "Conflicting approvers,Conflicting approvers",
+		},
+		{
+			name: "unknown special approver",
+			modify: func(c *policyConfig) {
+				c.SpecialApprover = types.StringUnknown()
+				c.UserApprovers = types.SetNull(testUserType)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			tt.modify(&config)
+			if got := diagnosticSummaries(validatePolicyApprovers(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyMinApprovals(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*policyConfig)
+		want   string
+	}{
+		{
+			name:   "not set",
+			modify: func(c *policyConfig) {},
+		},
+		{
+			name: "two of three",
+			modify: func(c *policyConfig) {
+				c.MinApprovals = types.Int64Value(2)
+				c.UserApprovers = testUsers("a@company.com", "b@company.com", "c@company.com")
+			},
+		},
+		{
+			name: "more than user approvers",
+			modify: func(c *policyConfig) {
+				c.MinApprovals = types.Int64Value(2)
+			},
+			want: "Invalid min_approvals",
+		},
+		{
+			name: "group approvers are not counted",
+			modify: func(c *policyConfig) {
+				c.MinApprovals = types.Int64Value(2)
+				c.GroupApprovers = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("group-1")})
+			},
+		},
+		{
+			name: "unknown user approvers",
+			modify: func(c *policyConfig) {
+				c.MinApprovals = types.Int64Value(2)
+				c.UserApprovers = types.SetUnknown(testUserType)
+			},
+		},
+		{
+			name: "zero",
+			modify: func(c *policyConfig) {
+				c.MinApprovals = types.Int64Value(0)
+			},
+			want: "Invalid min_approvals",
+		},
+		{
+			name: "approval behavior ALL",
+			modify: func(c *policyConfig) {
+				c.ApprovalBehavior = types.StringValue("all")
+				c.MinApprovals = types.Int64Value(1)
+			},
+			want: "Conflicting approval settings",
+		},
+		{
+			name: "special approver",
+			modify: func(c *policyConfig) {
+				c.SpecialApprover = types.StringValue("MANAGER")
+				c.MinApprovals = types.Int64Value(1)
+			},
+			want: "Conflicting approval settings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			tt.modify(&config)
+			if got := diagnosticSummaries(validatePolicyMinApprovals(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyApprovalStages(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages types.List
+		want   string
+	}{
+		{
+			name: "manager then security",
+			stages: testApprovalStages(
+				testApprovalStage("MANAGER", types.Int64Null(), types.SetNull(testUserType)),
+				testApprovalStage("", types.Int64Value(2), testUsers("a@company.com", "b@company.com")),
+			),
+		},
+		{
+			name:   "unknown stages",
+			stages: types.ListUnknown(testApprovalStageType),
+		},
+		{
+			name:   "stage without approvers",
+			stages: testApprovalStages(testApprovalStage("", types.Int64Null(), types.SetNull(testUserType))),
+			want:   "No approvers selected",
+		},
+		{
+			name:   "special approver with approvers",
+			stages: testApprovalStages(testApprovalStage("SELF", types.Int64Null(), testUsers("a@company.com"))),
+			want:   "Conflicting approvers",
+		},
+		{
+			name:   "min approvals above approvers",
+			stages: testApprovalStages(testApprovalStage("", types.Int64Value(2), testUsers("a@company.com"))),
+			want:   "Invalid min_approvals",
+		},
+		{
+			name:   "duplicate approvers",
+			stages: testApprovalStages(testApprovalStage("", types.Int64Null(), testUsers("a@company.com", "A@company.com"))),
+			want:   "Duplicate user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			config.UserApprovers = types.SetNull(testUserType)
+			config.ApprovalStages = tt.stages
+			if got := diagnosticSummaries(validatePolicyConfig(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyTTL(t *testing.T) {
+	tests := []struct {
+		name            string
+		specialApprover types.String
+		ttl             types.Int64
+		stages          types.List
+		want            string
+	}{
+		{
+			name:            "no ttl",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+		},
+		{
+			name:            "minimum",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Value(minPolicyTTL),
+		},
+		{
+			name:            "maximum",
+			specialApprover: types.StringValue("MANAGER"),
+			ttl:             types.Int64Value(maxPolicyTTL),
+		},
+		{
+			name:            "zero",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Value(0),
+			want:            "Invalid TTL",
+		},
+		{
+			name:            "too long",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Value(maxPolicyTTL + 1),
+			want:            "Invalid TTL",
+		},
+		{
+			name:            "auto",
+			specialApprover: types.StringValue("AUTO"),
+			ttl:             types.Int64Value(3600),
+			want:            "Auto policies cannot have a TTL",
+		},
+		{
+			name:            "auto lower case",
+			specialApprover: types.StringValue("auto"),
+			ttl:             types.Int64Value(3600),
+			want:            "Auto policies cannot have a TTL",
+		},
+		{
+			name:            "auto approval stage",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Value(3600),
+			stages: testApprovalStages(
+				testApprovalStage("MANAGER", types.Int64Null(), types.SetNull(testUserType)),
+				testApprovalStage("auto", types.Int64Null(), types.SetNull(testUserType)),
+			),
+			want: "Auto policies cannot have a TTL",
+		},
+		{
+			name:            "unknown",
+			specialApprover: types.StringValue("AUTO"),
+			ttl:             types.Int64Unknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			config.SpecialApprover = tt.specialApprover
+			config.TTL = tt.ttl
+			if !tt.stages.IsNull() {
+				config.ApprovalStages = tt.stages
+			}
+			if got := diagnosticSummaries(validatePolicyTTL(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyDurationTTL(t *testing.T) {
+	tests := []struct {
+		name            string
+		specialApprover types.String
+		ttl             types.Int64
+		defaultTTL      DurationValue
+		maxTTL          DurationValue
+		want            string
+	}{
+		{
+			name:            "default within maximum",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("4h"),
+			maxTTL:          NewDurationValue("1d"),
+		},
+		{
+			name:            "default equals maximum",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("60m"),
+			maxTTL:          NewDurationValue("1h"),
+		},
+		{
+			name:            "default without maximum",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("4h"),
+			maxTTL:          DurationNull(),
+		},
+		{
+			name:            "default above maximum",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("8h"),
+			maxTTL:          NewDurationValue("4h"),
+			want:            "Invalid default_ttl",
+		},
+		{
+			name:            "default above legacy ttl",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Value(3600),
+			defaultTTL:      NewDurationValue("2h"),
+			maxTTL:          DurationNull(),
+			want:            "Invalid default_ttl",
+		},
+		{
+			name:            "unknown maximum",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("8h"),
+			maxTTL:          DurationUnknown(),
+		},
+		{
+			name:            "invalid duration",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("8 hours"),
+			maxTTL:          NewDurationValue("4h"),
+		},
+		{
+			name:            "too short",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      DurationNull(),
+			maxTTL:          NewDurationValue("30s"),
+			want:            "Invalid TTL",
+		},
+		{
+			name:            "too long",
+			specialApprover: types.StringNull(),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("366d"),
+			maxTTL:          DurationNull(),
+			want:            "Invalid TTL",
+		},
+		{
+			name:            "auto",
+			specialApprover: types.StringValue("auto"),
+			ttl:             types.Int64Null(),
+			defaultTTL:      NewDurationValue("1h"),
+			maxTTL:          NewDurationValue("4h"),
+			want:            "Auto policies cannot have a TTL,Auto policies cannot have a TTL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			config.SpecialApprover = tt.specialApprover
+			config.TTL = tt.ttl
+			config.DefaultTTL = tt.defaultTTL
+			config.MaxTTL = tt.maxTTL
+			if got := diagnosticSummaries(validatePolicyTTL(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyExtensions(t *testing.T) {
+	tests := []struct {
+		name          string
+		allow         types.Bool
+		maxExtensions types.Int64
+		maxTTL        DurationValue
+		want          string
+	}{
+		{"not set", types.BoolNull(), types.Int64Null(), DurationNull(), ""},
+		{"allowed", types.BoolValue(true), types.Int64Value(2), NewDurationValue("4h"), ""},
+		{"unknown allowance", types.BoolUnknown(), types.Int64Value(2), DurationNull(), ""},
+		{"unknown ttl", types.BoolValue(true), types.Int64Null(), DurationUnknown(), ""},
+		{"not allowed", types.BoolValue(false), types.Int64Value(2), NewDurationValue("4h"), "Extensions not allowed"},
+		{"default", types.BoolNull(), types.Int64Value(2), NewDurationValue("4h"), "Extensions not allowed"},
+		{"no ttl", types.BoolValue(true), types.Int64Null(), DurationNull(), "Extensions require a TTL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			config.AllowExtension = tt.allow
+			config.MaxExtensions = tt.maxExtensions
+			config.MaxTTL = tt.maxTTL
+			if got := diagnosticSummaries(validatePolicyExtensions(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyAccessWindows(t *testing.T) {
+	weekdays := []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"}
+	london := types.StringValue("Europe/London")
+	tests := []struct {
+		name    string
+		windows []attr.Value
+		want    string
+	}{
+		{
+			name: "business hours and on-call",
+			windows: []attr.Value{
+				testAccessWindow(weekdays, "09:00", "17:30", london),
+				testAccessWindow([]string{"saturday", "sunday"}, "00:00", "24:00", london),
+			},
+		},
+		{
+			name: "overlapping",
+			windows: []attr.Value{
+				testAccessWindow(weekdays, "09:00", "17:30", london),
+				testAccessWindow([]string{"FRIDAY"}, "17:00", "09:00", london),
+			},
+			want: "Overlapping access windows",
+		},
+		{
+			name: "overlapping in another time zone",
+			windows: []attr.Value{
+				testAccessWindow(weekdays, "09:00", "17:30", london),
+				testAccessWindow(weekdays, "09:00", "17:30", types.StringValue("America/New_York")),
+			},
+		},
+		{
+			name: "unknown time zone",
+			windows: []attr.Value{
+				testAccessWindow(weekdays, "09:00", "17:30", london),
+				testAccessWindow(weekdays, "09:00", "17:30", types.StringUnknown()),
+			},
+		},
+		{
+			name: "empty",
+			windows: []attr.Value{
+				testAccessWindow(weekdays, "09:00", "09:00", london),
+				testAccessWindow(weekdays, "10:00", "11:00", london),
+			},
+			want: "Invalid access window",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			config.AccessWindows = types.ListValueMust(testAccessWindowType, tt.windows)
+			if got := diagnosticSummaries(validatePolicyAccessWindows(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyJustification(t *testing.T) {
+	tests := []struct {
+		name      string
+		require   types.Bool
+		minLength types.Int64
+		want      string
+	}{
+		{"not set", types.BoolNull(), types.Int64Null(), ""},
+		{"required", types.BoolValue(true), types.Int64Value(20), ""},
+		{"unknown requirement", types.BoolUnknown(), types.Int64Value(20), ""},
+		{"not required", types.BoolValue(false), types.Int64Value(20), "Justification not required"},
+		{"default", types.BoolNull(), types.Int64Value(20), "Justification not required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			config.RequireJustification = tt.require
+			config.JustificationMinLength = tt.minLength
+			if got := diagnosticSummaries(validatePolicyJustification(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyEntitlements(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*policyConfig)
+		want   string
+	}{
+		{
+			name:   "valid",
+			modify: func(c *policyConfig) {},
+		},
+		{
+			name: "no entitlements",
+			modify: func(c *policyConfig) {
+				c.Entitlements = testEntitlements()
+			},
+			want: "No entitlements selected",
+		},
+		{
+			name: "unknown entitlements",
+			modify: func(c *policyConfig) {
+				c.Entitlements = types.SetUnknown(testEntitlementType)
+			},
+		},
+		{
+			name: "duplicate entitlements ignoring case",
+			modify: func(c *policyConfig) {
+				c.Entitlements = testEntitlements([3]string{"AWS", "ROLE", "admin"}, [3]string{"aws", "role", "ADMIN"})
+			},
+			want: "Duplicate entitlement",
+		},
+		{
+			name: "same entitlement granted and approving",
+			modify: func(c *policyConfig) {
+				c.EntitlementApprovers = testEntitlements([3]string{"AWS", "ROLE", "admin"})
+			},
+		},
+		{
+			name: "duplicate entitlement approvers",
+			modify: func(c *policyConfig) {
+				c.EntitlementApprovers = testEntitlements([3]string{"OKTA", "GROUP", "a"}, [3]string{"OKTA", "GROUP", "A"})
+			},
+			want: "Duplicate entitlement",
+		},
+		{
+			name: "duplicate condition entitlements",
+			modify: func(c *policyConfig) {
+				c.Condition = testCondition(testEntitlements([3]string{"OKTA", "GROUP", "eng"}, [3]string{"Okta", "Group", "eng"}))
+			},
+			want: "Duplicate entitlement",
+		},
+		{
+			name: "duplicate approvers ignoring case",
+			modify: func(c *policyConfig) {
+				c.UserApprovers = testUsers("approver@company.com", "Approver@Company.com")
+			},
+			want: "Duplicate user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testValidPolicyConfig()
+			tt.modify(&config)
+			if got := diagnosticSummaries(validatePolicyEntitlements(config)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConditionThresholds(t *testing.T) {
+	two := testEntitlements([3]string{"OKTA", "GROUP", "eng"}, [3]string{"OKTA", "GROUP", "contractors"})
+	nested := testThresholdCondition("NONE", types.Int64Value(1), two)
+	nestedType := nested.Type(context.Background())
+
+	tests := []struct {
+		name      string
+		condition types.Object
+		want      string
+	}{
+		{"no threshold", testCondition(two), ""},
+		{"at least", testThresholdCondition("at_least", types.Int64Value(2), two), ""},
+		{"at least unknown threshold", testThresholdCondition("AT_LEAST", types.Int64Unknown(), two), ""},
+		{"at least unknown entitlements", testThresholdCondition("AT_LEAST", types.Int64Value(5), types.SetUnknown(testEntitlementType)), ""},
+		{"at least without threshold", testThresholdCondition("AT_LEAST", types.Int64Null(), two), "Invalid threshold"},
+		{"zero", testThresholdCondition("AT_LEAST", types.Int64Value(0), two), "Invalid threshold"},
+		{"above entitlements", testThresholdCondition("AT_LEAST", types.Int64Value(3), two), "Invalid threshold"},
+		{"threshold without at least", testThresholdCondition("ANY", types.Int64Value(1), two), "Invalid threshold"},
+		{
+			name: "nested",
+			condition: types.ObjectValueMust(map[string]attr.Type{
+				"quantifier":    types.StringType,
+				"subconditions": types.SetType{ElemType: nestedType},
+			}, map[string]attr.Value{
+				"quantifier":    types.StringValue("ALL"),
+				"subconditions": types.SetValueMust(nestedType, []attr.Value{nested}),
+			}),
+			want: "Invalid threshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diagnosticSummaries(validateConditionThresholds(tt.condition, path.Root("condition"))); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreserveCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		current types.String
+		value   string
+		want    types.String
+	}{
+		{"null", types.StringNull(), "ALL", types.StringValue("ALL")},
+		{"unknown", types.StringUnknown(), "ALL", types.StringValue("ALL")},
+		{"same case", types.StringValue("ALL"), "ALL", types.StringValue("ALL")},
+		{"different case", types.StringValue("all"), "ALL", types.StringValue("all")},
+		{"changed", types.StringValue("any"), "ALL", types.StringValue("ALL")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preserveCase(tt.current, tt.value); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
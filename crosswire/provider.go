@@ -2,13 +2,17 @@ package crosswire
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -26,8 +30,12 @@ type CrosswireProvider struct {
 
 // ScaffoldingProviderModel describes the provider data model.
 type CrosswireProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	ApiToken types.String `tfsdk:"api_token"`
+	Host             types.String `tfsdk:"host"`
+	ApiToken         types.String `tfsdk:"api_token"`
+	MaxRetries       types.Int64  `tfsdk:"max_retries"`
+	RequestTimeout   types.Int64  `tfsdk:"request_timeout"`
+	StrictValidation types.Bool   `tfsdk:"strict_validation"`
+	ValidateUsers    types.Bool   `tfsdk:"validate_users"`
 }
 
 func (p *CrosswireProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,6 +54,28 @@ func (p *CrosswireProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a request is retried after a network error, `429`, or `5xx` response. Requests that are not idempotent are only retried on `429`. Defaults to `%d`.", DefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Timeout in seconds for each HTTP request to the Crosswire API. Defaults to `%d`.", int64(DefaultRequestTimeout/time.Second)),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"strict_validation": schema.BoolAttribute{
+				MarkdownDescription: "Check every entitlement referenced by a `crosswire_policy` against Crosswire at plan time, failing the plan on provider/subject/object tuples missing from the entitlement catalog. Entitlements declared with `crosswire_entitlement` in the same configuration count as known. Also rejects `crosswire_group` `nested_groups` that would form a cycle. Defaults to `false`.",
+				Optional:            true,
+			},
+			"validate_users": schema.BoolAttribute{
+				MarkdownDescription: "Check policy owners and approvers, and group members and owners, against Crosswire users at plan time, failing the plan on email addresses that are unknown or deactivated and suggesting close matches. Defaults to `true`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -113,7 +143,21 @@ func (p *CrosswireProvider) Configure(ctx context.Context, req provider.Configur
 	ctx = tflog.SetField(ctx, "crosswire_api_token", apiToken)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "crosswire_api_token")
 
-	client, err := NewClient(&host, &apiToken)
+	var opts []ClientOption
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		opts = append(opts, WithMaxRetries(int(config.MaxRetries.ValueInt64())))
+	}
+	if !config.RequestTimeout.IsNull() && !config.RequestTimeout.IsUnknown() {
+		opts = append(opts, WithRequestTimeout(time.Duration(config.RequestTimeout.ValueInt64())*time.Second))
+	}
+	if !config.StrictValidation.IsNull() && !config.StrictValidation.IsUnknown() {
+		opts = append(opts, WithStrictValidation(config.StrictValidation.ValueBool()))
+	}
+	if !config.ValidateUsers.IsNull() && !config.ValidateUsers.IsUnknown() {
+		opts = append(opts, WithValidateUsers(config.ValidateUsers.ValueBool()))
+	}
+
+	client, err := NewClient(ctx, &host, &apiToken, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Crosswire API Client",
@@ -132,11 +176,19 @@ func (p *CrosswireProvider) Configure(ctx context.Context, req provider.Configur
 func (p *CrosswireProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewPolicyResource,
+		NewEntitlementResource,
+		NewGroupResource,
 	}
 }
 
 func (p *CrosswireProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewPolicyDataSource,
+		NewPoliciesDataSource,
+		NewEntitlementsDataSource,
+		NewUserDataSource,
+		NewUsersDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -1,6 +1,7 @@
 package crosswire
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -16,8 +17,36 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"crosswire": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// TestMain points the provider at an in-memory fake of the Crosswire API
+// when CROSSWIRE_FAKE_API is set, so acceptance tests can run offline.
+func TestMain(m *testing.M) {
+	if os.Getenv("CROSSWIRE_FAKE_API") == "" {
+		os.Exit(m.Run())
+	}
+
+	server, fake := newFakeServer()
+	testAccFakeServer = fake
+	os.Setenv("CROSSWIRE_API_HOST", server.URL)
+	os.Setenv("CROSSWIRE_API_TOKEN", fakeAPIToken)
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func testAccPreCheck(t *testing.T) {
 	if err := os.Getenv("CROSSWIRE_API_TOKEN"); err == "" {
 		t.Fatal("CROSSWIRE_API_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccClient builds a client from the same environment variables the
+// provider reads so checks can inspect Crosswire directly.
+func testAccClient() (*Client, error) {
+	host := os.Getenv("CROSSWIRE_API_HOST")
+	if host == "" {
+		host = HostURL
+	}
+	token := os.Getenv("CROSSWIRE_API_TOKEN")
+	return NewClient(context.Background(), &host, &token)
+}
@@ -0,0 +1,174 @@
+package crosswire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigure = &UserDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource defines the data source implementation.
+type UserDataSource struct {
+	client *Client
+}
+
+// UserDataSourceModel describes the data source data model. manager uses
+// UserModel so it has the same shape as a policy's owner.
+type UserDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	EmailAddress types.String `tfsdk:"email_address"`
+	Name         types.String `tfsdk:"name"`
+	Manager      *UserModel   `tfsdk:"manager"`
+	Department   types.String `tfsdk:"department"`
+	Active       types.Bool   `tfsdk:"active"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// dataSourceUserDetailAttributesV0 returns the read-only attributes describing
+// a user, shared by the crosswire_user and crosswire_users data sources.
+func dataSourceUserDetailAttributesV0() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "Crosswire user id",
+		},
+		"email_address": schema.StringAttribute{
+			Computed:    true,
+			Description: "Email address of the user",
+		},
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "Display name of the user",
+		},
+		"manager": schema.SingleNestedAttribute{
+			Computed:    true,
+			Attributes:  dataSourceUserAttributesV0(),
+			Description: "The user's manager, or null if they have none.",
+		},
+		"department": schema.StringAttribute{
+			Computed:    true,
+			Description: "Department the user belongs to",
+		},
+		"active": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the user is active. Deactivated users cannot own or approve policies.",
+		},
+	}
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataSourceUserDetailAttributesV0()
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Crosswire user id. Exactly one of id or email_address must be set.",
+	}
+	attributes["email_address"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Email address of the user, case insensitive. Exactly one of id or email_address must be set.",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Crosswire user by `id` or `email_address`.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *UserDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("email_address"),
+		),
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var id, email types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("email_address"), &email)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var user *User
+	var err error
+	if !id.IsNull() {
+		user, err = d.client.getUser(ctx, id.ValueString())
+	} else {
+		user, err = d.client.getUserByEmail(ctx, email.ValueString())
+	}
+	if errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"User not found",
+			"No Crosswire user matched the given id or email address: "+err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			"Could not read user, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state := userToDataSourceModelConverter(user)
+
+	tflog.Trace(ctx, "read a data source", map[string]any{"id": state.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func userToDataSourceModelConverter(user *User) UserDataSourceModel {
+	state := UserDataSourceModel{
+		Id:           types.StringValue(user.Id),
+		EmailAddress: types.StringValue(user.EmailAddress),
+		Name:         optionalStringValue(user.Name),
+		Department:   optionalStringValue(user.Department),
+		Active:       types.BoolValue(user.Active),
+	}
+	if user.Manager != "" {
+		state.Manager = &UserModel{EmailAddress: types.StringValue(user.Manager)}
+	}
+	return state
+}
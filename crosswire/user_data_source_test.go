@@ -0,0 +1,42 @@
+package crosswire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.crosswire_user.by_email", "id"),
+					resource.TestCheckResourceAttr("data.crosswire_user.by_email", "email_address", "user@company.com"),
+					resource.TestCheckResourceAttr("data.crosswire_user.by_email", "manager.email_address", "approver@company.com"),
+					resource.TestCheckResourceAttr("data.crosswire_user.by_email", "active", "true"),
+					resource.TestCheckResourceAttrPair("data.crosswire_user.by_id", "id", "data.crosswire_user.manager", "id"),
+					resource.TestCheckResourceAttr("data.crosswire_user.by_id", "email_address", "approver@company.com"),
+					resource.TestCheckNoResourceAttr("data.crosswire_user.by_id", "manager"),
+				),
+			},
+		},
+	})
+}
+
+const testAccUserDataSourceConfig = `
+data "crosswire_user" "by_email" {
+  email_address = "USER@company.com"
+}
+
+data "crosswire_user" "manager" {
+  email_address = data.crosswire_user.by_email.manager.email_address
+}
+
+data "crosswire_user" "by_id" {
+  id = data.crosswire_user.manager.id
+}
+`
@@ -0,0 +1,225 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxUserSuggestions caps how many close matches are offered for an unknown
+// email address.
+const maxUserSuggestions = 3
+
+// userDirectory caches user lookups for the lifetime of a provider instance.
+// Emails are keyed in lower case; a nil entry records a lookup that found no
+// user.
+type userDirectory struct {
+	mu     sync.Mutex
+	byMail map[string]*User
+	all    []User
+	listed bool
+}
+
+// resolveUsers looks up every email not already cached in a single request
+// and returns the cached result for each of them.
+func (c *Client) resolveUsers(ctx context.Context, emails []string) (map[string]*User, error) {
+	c.users.mu.Lock()
+	defer c.users.mu.Unlock()
+
+	if c.users.byMail == nil {
+		c.users.byMail = map[string]*User{}
+	}
+
+	var missing []string
+	for _, email := range emails {
+		key := strings.ToLower(email)
+		if _, ok := c.users.byMail[key]; !ok {
+			missing = append(missing, email)
+			c.users.byMail[key] = nil
+		}
+	}
+
+	if len(missing) > 0 {
+		users, err := c.lookupUsers(ctx, missing)
+		if err != nil {
+			for _, email := range missing {
+				delete(c.users.byMail, strings.ToLower(email))
+			}
+			return nil, err
+		}
+		for i := range users {
+			c.users.byMail[strings.ToLower(users[i].EmailAddress)] = &users[i]
+		}
+	}
+
+	resolved := make(map[string]*User, len(emails))
+	for _, email := range emails {
+		resolved[strings.ToLower(email)] = c.users.byMail[strings.ToLower(email)]
+	}
+	return resolved, nil
+}
+
+// suggestUsers returns the active users whose email addresses are closest to
+// email, listing the organization's users on first use.
+func (c *Client) suggestUsers(ctx context.Context, email string) ([]string, error) {
+	c.users.mu.Lock()
+	defer c.users.mu.Unlock()
+
+	if !c.users.listed {
+		users, err := c.listUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.users.all = users
+		c.users.listed = true
+	}
+
+	email = strings.ToLower(email)
+	// Allow roughly one edit per three characters of the local part, so typos
+	// match but unrelated addresses sharing the domain do not.
+	local := email
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		local = email[:at]
+	}
+	threshold := len(local)/3 + 1
+
+	type candidate struct {
+		email    string
+		distance int
+	}
+	var candidates []candidate
+	for _, user := range c.users.all {
+		if !user.Active {
+			continue
+		}
+		if distance := levenshtein(email, strings.ToLower(user.EmailAddress)); distance <= threshold {
+			candidates = append(candidates, candidate{user.EmailAddress, distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].email < candidates[j].email
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxUserSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].email)
+	}
+	return suggestions, nil
+}
+
+// userReference is an email address used by a policy and where it was set.
+type userReference struct {
+	email string
+	path  path.Path
+}
+
+// userSetReferences collects the known email addresses of a set of
+// email_address objects, such as user_approvers.
+func userSetReferences(set types.Set, p path.Path) []userReference {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var references []userReference
+	for _, element := range set.Elements() {
+		user, ok := element.(types.Object)
+		if !ok || user.IsNull() || user.IsUnknown() {
+			continue
+		}
+		email, ok := user.Attributes()["email_address"].(types.String)
+		if !ok || email.IsNull() || email.IsUnknown() {
+			continue
+		}
+		references = append(references, userReference{email.ValueString(), p.AtSetValue(element).AtName("email_address")})
+	}
+	return references
+}
+
+// checkUsers adds an error for every reference to an email address that does
+// not belong to an active Crosswire user.
+func (c *Client) checkUsers(ctx context.Context, references []userReference, diags *diag.Diagnostics) {
+	if len(references) == 0 {
+		return
+	}
+
+	emails := make([]string, 0, len(references))
+	for _, reference := range references {
+		emails = append(emails, reference.email)
+	}
+	users, err := c.resolveUsers(ctx, emails)
+	if err != nil {
+		diags.AddError(
+			"Error Looking Up Users",
+			"Could not look up users for validate_users, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, reference := range references {
+		user := users[strings.ToLower(reference.email)]
+		if user != nil && user.Active {
+			continue
+		}
+		if user != nil {
+			diags.AddAttributeError(
+				reference.path,
+				"Deactivated user",
+				fmt.Sprintf("User %q is deactivated in Crosswire and cannot own or approve policies.", reference.email),
+			)
+			continue
+		}
+
+		detail := fmt.Sprintf("No Crosswire user has the email address %q.", reference.email)
+		suggestions, err := c.suggestUsers(ctx, reference.email)
+		if err != nil {
+			diags.AddError(
+				"Error Listing Users",
+				"Could not list users for validate_users, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if len(suggestions) > 0 {
+			detail += " Did you mean " + strings.Join(suggestions, ", ") + "?"
+		}
+		diags.AddAttributeError(reference.path, "Unknown user", detail)
+	}
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
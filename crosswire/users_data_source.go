@@ -0,0 +1,149 @@
+package crosswire
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &UsersDataSource{}
+var _ datasource.DataSourceWithConfigure = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client *Client
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	Department          types.String          `tfsdk:"department"`
+	ManagerEmailAddress types.String          `tfsdk:"manager_email_address"`
+	Active              types.Bool            `tfsdk:"active"`
+	Users               []UserDataSourceModel `tfsdk:"users"`
+	UserApprovers       []UserModel           `tfsdk:"user_approvers"`
+
+	Id types.String `tfsdk:"id"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Crosswire users, optionally filtered. All filters that are set must match for a user to be returned.",
+		Attributes: map[string]schema.Attribute{
+			"department": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return users in this department. Case insensitive.",
+			},
+			"manager_email_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return users reporting directly to this email address. Case insensitive.",
+			},
+			"active": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return active (true) or deactivated (false) users.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dataSourceUserDetailAttributesV0(),
+				},
+				Description: "Matching users, sorted by email address.",
+			},
+			"user_approvers": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dataSourceUserAttributesV0(),
+				},
+				Description: "Matching users in the shape of a policy's user_approvers, so the list can be assigned to it directly.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Placeholder identifier for this data source.",
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.listUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Users",
+			"Could not list users, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return strings.ToLower(users[i].EmailAddress) < strings.ToLower(users[j].EmailAddress)
+	})
+
+	data.Users = []UserDataSourceModel{}
+	data.UserApprovers = []UserModel{}
+	for i := range users {
+		if !data.matches(users[i]) {
+			continue
+		}
+		data.Users = append(data.Users, userToDataSourceModelConverter(&users[i]))
+		data.UserApprovers = append(data.UserApprovers, UserModel{EmailAddress: types.StringValue(users[i].EmailAddress)})
+	}
+	data.Id = types.StringValue("crosswire_users")
+
+	tflog.Trace(ctx, "read a data source", map[string]any{"users": len(data.Users), "total": len(users)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches reports whether user satisfies every filter set on the model.
+func (data UsersDataSourceModel) matches(user User) bool {
+	if !data.Department.IsNull() && !strings.EqualFold(user.Department, data.Department.ValueString()) {
+		return false
+	}
+	if !data.ManagerEmailAddress.IsNull() && !strings.EqualFold(user.Manager, data.ManagerEmailAddress.ValueString()) {
+		return false
+	}
+	if !data.Active.IsNull() && user.Active != data.Active.ValueBool() {
+		return false
+	}
+	return true
+}
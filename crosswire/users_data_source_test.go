@@ -0,0 +1,127 @@
+package crosswire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUsersDataSource(t *testing.T) {
+	name := RandomStringGenerator(16)
+	data_source := fmt.Sprintf("data.crosswire_users.%s", name)
+	terraform_resource := fmt.Sprintf("crosswire_policy.%s", name)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUsersDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(data_source, "users.#", "2"),
+					resource.TestCheckResourceAttr(data_source, "users.0.email_address", "approver@company.com"),
+					resource.TestCheckResourceAttr(data_source, "users.0.department", "Security"),
+					resource.TestCheckResourceAttr(data_source, "users.1.email_address", "second.approver@company.com"),
+					resource.TestCheckResourceAttr(data_source, "user_approvers.#", "2"),
+					resource.TestCheckResourceAttr(terraform_resource, "user_approvers.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(terraform_resource, "user_approvers.*", map[string]string{
+						"email_address": "second.approver@company.com"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccUsersDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+data "crosswire_users" "%[1]s" {
+  department = "security"
+  active     = true
+}
+
+resource "crosswire_policy" "%[1]s" {
+  owner = {
+    email_address = "user@company.com"
+  }
+  name = "%[1]s"
+  entitlements = [
+    {
+      provider = "CROSSWIRE"
+      subject  = "CREATE"
+      object   = "ENTITLEMENT"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ADMIN"
+      }
+    ]
+  }
+  user_approvers = data.crosswire_users.%[1]s.user_approvers
+}
+`, name)
+}
+
+func TestUsersDataSourceModelMatches(t *testing.T) {
+	user := User{
+		EmailAddress: "user@company.com",
+		Manager:      "manager@company.com",
+		Department:   "Engineering",
+		Active:       true,
+	}
+
+	tests := []struct {
+		name  string
+		model UsersDataSourceModel
+		want  bool
+	}{
+		{
+			name: "no filters",
+			want: true,
+		},
+		{
+			name:  "department case insensitive",
+			model: UsersDataSourceModel{Department: types.StringValue("engineering")},
+			want:  true,
+		},
+		{
+			name:  "other department",
+			model: UsersDataSourceModel{Department: types.StringValue("Security")},
+			want:  false,
+		},
+		{
+			name:  "manager case insensitive",
+			model: UsersDataSourceModel{ManagerEmailAddress: types.StringValue("Manager@Company.com")},
+			want:  true,
+		},
+		{
+			name:  "other manager",
+			model: UsersDataSourceModel{ManagerEmailAddress: types.StringValue("user@company.com")},
+			want:  false,
+		},
+		{
+			name:  "active",
+			model: UsersDataSourceModel{Active: types.BoolValue(true)},
+			want:  true,
+		},
+		{
+			name:  "deactivated",
+			model: UsersDataSourceModel{Active: types.BoolValue(false)},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.matches(user); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
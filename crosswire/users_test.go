@@ -0,0 +1,92 @@
+package crosswire
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"approver", "approver", 0},
+		{"aprover", "approver", 1},
+		{"approevr", "approver", 2},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheckUsers(t *testing.T) {
+	lookups := 0
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/integrations/crosswire_terraform/users/lookup":
+			lookups++
+			var lookup userLookupRequest
+			if err := json.NewDecoder(r.Body).Decode(&lookup); err != nil {
+				t.Fatal(err)
+			}
+			users := []User{}
+			for _, email := range lookup.EmailAddresses {
+				for _, user := range fakeUsers {
+					if strings.EqualFold(user.EmailAddress, email) {
+						users = append(users, user)
+					}
+				}
+			}
+			writeFakeJSON(w, map[string]any{"users": users})
+		case "/integrations/crosswire_terraform/users":
+			writeFakeJSON(w, map[string]any{"users": fakeUsers})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	references := []userReference{
+		{"USER@company.com", path.Root("owner").AtName("email_address")},
+		{"aprover@company.com", path.Root("user_approvers")},
+		{"former.employee@company.com", path.Root("user_approvers")},
+		{"nobody.at.all@example.org", path.Root("user_approvers")},
+	}
+
+	var diags diag.Diagnostics
+	client.checkUsers(context.Background(), references, &diags)
+
+	var summaries, details []string
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary())
+		details = append(details, d.Detail())
+	}
+	if got, want := strings.Join(summaries, ","), "Unknown user,Deactivated user,Unknown user"; got != want {
+		t.Fatalf("got errors %q, want %q", got, want)
+	}
+	if !strings.HasSuffix(details[0], "Did you mean approver@company.com?") {
+		t.Errorf("expected a suggestion for the typo, got %q", details[0])
+	}
+	if strings.Contains(details[2], "Did you mean") {
+		t.Errorf("expected no suggestion for an unrelated address, got %q", details[2])
+	}
+
+	// Results are cached for the rest of the run.
+	diags = nil
+	client.checkUsers(context.Background(), references[:1], &diags)
+	if diags.HasError() || lookups != 1 {
+		t.Errorf("expected a cached lookup, got %d lookups and %v", lookups, diags)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"regexp"
 	"time"
 	"unsafe"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -35,8 +37,10 @@ func (m stringDefaultModifier) MarkdownDescription(ctx context.Context) string {
 // `resp` contains fields for updating the planned value, triggering resource
 // replacement, and returning diagnostics.
 func (m stringDefaultModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
-	// If the value is unknown or known, do not set default value.
-	if !req.PlanValue.IsNull() {
+	// If the value is configured, do not set default value. Unconfigured
+	// computed attributes arrive here as unknown, so check the config rather
+	// than the plan.
+	if !req.ConfigValue.IsNull() {
 		return
 	}
 
@@ -49,6 +53,97 @@ func StringDefault(defaultValue string) planmodifier.String {
 	}
 }
 
+// boolDefaultModifier is the types.BoolType counterpart of
+// stringDefaultModifier, with the same requirements.
+type boolDefaultModifier struct {
+	Default bool
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (m boolDefaultModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("If value is not configured, defaults to %t", m.Default)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (m boolDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("If value is not configured, defaults to `%t`", m.Default)
+}
+
+// PlanModifyBool runs the logic of the plan modifier.
+func (m boolDefaultModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// If the value is configured, do not set default value.
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.PlanValue = types.BoolValue(m.Default)
+}
+
+func BoolDefault(defaultValue bool) planmodifier.Bool {
+	return boolDefaultModifier{
+		Default: defaultValue,
+	}
+}
+
+// regexpValidator checks that a string attribute is a valid RE2 regular
+// expression, the syntax Crosswire evaluates patterns with.
+type regexpValidator struct{}
+
+var _ validator.String = regexpValidator{}
+
+func (v regexpValidator) Description(ctx context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid regular expression",
+			fmt.Sprintf("%s is not a valid RE2 regular expression: %s", req.Path, err.Error()),
+		)
+	}
+}
+
+// timeZoneValidator checks that a string attribute is an IANA time zone name,
+// such as Europe/Berlin.
+type timeZoneValidator struct{}
+
+var _ validator.String = timeZoneValidator{}
+
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return "value must be an IANA time zone name"
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// LoadLocation maps "" to UTC and "Local" to the machine's time zone,
+	// neither of which Crosswire knows about.
+	value := req.ConfigValue.ValueString()
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid time zone",
+			fmt.Sprintf("%s must be an IANA time zone name such as Europe/Berlin or America/New_York, got %q.", req.Path, value),
+		)
+	}
+}
+
 func ToPointer[T any](t T) *T {
 	return &t
 }
@@ -0,0 +1,35 @@
+package crosswire
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringDefaultModifier(t *testing.T) {
+	tests := []struct {
+		name   string
+		config types.String
+		plan   types.String
+		want   types.String
+	}{
+		// Terraform plans unconfigured computed attributes as unknown, so
+		// checking the plan value alone never applied the default.
+		{"not configured", types.StringNull(), types.StringUnknown(), types.StringValue("NONE")},
+		{"configured", types.StringValue("MANAGER"), types.StringValue("MANAGER"), types.StringValue("MANAGER")},
+		{"configured from unknown value", types.StringUnknown(), types.StringUnknown(), types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{ConfigValue: tt.config, PlanValue: tt.plan}
+			resp := &planmodifier.StringResponse{PlanValue: tt.plan}
+			StringDefault("NONE").PlanModifyString(context.Background(), req, resp)
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("got %s, want %s", resp.PlanValue, tt.want)
+			}
+		})
+	}
+}
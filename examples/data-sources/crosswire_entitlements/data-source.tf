@@ -0,0 +1,37 @@
+# Every GitHub team membership in the catalog
+data "crosswire_entitlements" "github_teams" {
+  provider_name  = "GITHUB"
+  subject_prefix = "TEAM_"
+}
+
+# One policy per team, approved by the team's maintainers
+resource "crosswire_policy" "github_team" {
+  for_each = { for entitlement in data.crosswire_entitlements.github_teams.entitlements : entitlement.object => entitlement }
+
+  owner = {
+    email_address = "user@crosswire.io"
+  }
+  name = "GitHub ${each.key}"
+  entitlements = [
+    {
+      provider = each.value.provider
+      subject  = each.value.subject
+      object   = each.value.object
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ENGINEER"
+      }
+    ]
+  }
+  user_approvers = [
+    {
+      email_address = "approver@crosswire.io"
+    }
+  ]
+}
@@ -0,0 +1,10 @@
+# Every active policy granting membership of a GitHub team
+data "crosswire_policies" "github_teams" {
+  state                = "ACTIVE"
+  entitlement_provider = "GITHUB"
+  entitlement_subject  = "TEAM"
+}
+
+output "github_team_policies" {
+  value = { for policy in data.crosswire_policies.github_teams.policies : policy.name => policy.id }
+}
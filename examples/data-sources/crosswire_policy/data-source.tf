@@ -0,0 +1,7 @@
+data "crosswire_policy" "by_id" {
+  id = "INSERT-POLICY-ID-HERE"
+}
+
+data "crosswire_policy" "by_name" {
+  name = "Prod DB Admin"
+}
@@ -0,0 +1,8 @@
+data "crosswire_user" "engineer" {
+  email_address = "user@crosswire.io"
+}
+
+# The engineer's manager, e.g. to make them the owner of a policy
+data "crosswire_user" "manager" {
+  email_address = data.crosswire_user.engineer.manager.email_address
+}
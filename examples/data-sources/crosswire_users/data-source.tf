@@ -0,0 +1,30 @@
+# Every active member of the security team
+data "crosswire_users" "security" {
+  department = "Security"
+  active     = true
+}
+
+resource "crosswire_policy" "prod_access" {
+  owner = {
+    email_address = "user@crosswire.io"
+  }
+  name = "Production access"
+  entitlements = [
+    {
+      provider = "AWS"
+      subject  = "ROLE"
+      object   = "prod-admin"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ENGINEER"
+      }
+    ]
+  }
+  user_approvers = data.crosswire_users.security.user_approvers
+}
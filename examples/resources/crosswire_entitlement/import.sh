@@ -0,0 +1,2 @@
+# Entitlements can be imported by their Crosswire id
+terraform import crosswire_entitlement.resource_name 0123456789abcdef
@@ -0,0 +1,37 @@
+resource "crosswire_entitlement" "db_admin" {
+  provider_name = "AWS"
+  subject       = "ROLE"
+  object        = "prod-db-admin"
+  description   = "Administrative access to the production database"
+  risk_level    = "HIGH"
+  integration   = "aws-prod"
+}
+
+resource "crosswire_policy" "db_admin" {
+  owner = {
+    email_address = "user@crosswire.io"
+  }
+  name = "Prod DB Admin"
+  entitlements = [
+    {
+      provider = crosswire_entitlement.db_admin.provider_name
+      subject  = crosswire_entitlement.db_admin.subject
+      object   = crosswire_entitlement.db_admin.object
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ENGINEER"
+      }
+    ]
+  }
+  user_approvers = [
+    {
+      email_address = "approver@crosswire.io"
+    }
+  ]
+}
@@ -0,0 +1,2 @@
+# Groups can be imported by their Crosswire id
+terraform import crosswire_group.resource_name 0123456789abcdef
@@ -0,0 +1,49 @@
+resource "crosswire_group" "dba_oncall" {
+  name = "DBA on-call"
+  members = [
+    {
+      email_address = "dba@crosswire.io"
+    }
+  ]
+}
+
+resource "crosswire_group" "db_approvers" {
+  name        = "Database approvers"
+  description = "Approves access to production databases"
+  members = [
+    {
+      email_address = "approver@crosswire.io"
+    }
+  ]
+  nested_groups = [crosswire_group.dba_oncall.id]
+  owners = [
+    {
+      email_address = "user@crosswire.io"
+    }
+  ]
+}
+
+resource "crosswire_policy" "db_admin" {
+  owner = {
+    email_address = "user@crosswire.io"
+  }
+  name = "Prod DB Admin"
+  entitlements = [
+    {
+      provider = "AWS"
+      subject  = "ROLE"
+      object   = "prod-db-admin"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "CROSSWIRE"
+        subject  = "ROLE"
+        object   = "ENGINEER"
+      }
+    ]
+  }
+  group_approvers = [crosswire_group.db_approvers.id]
+}
@@ -0,0 +1,5 @@
+# Policies can be imported by their Crosswire id
+terraform import crosswire_policy.resource_name 0123456789abcdef
+
+# or by their exact name, prefixed with "name:"
+terraform import crosswire_policy.resource_name "name:Prod DB Admin"
@@ -60,4 +60,138 @@ resource "crosswire_policy" "resource_name" {
     }
   ]
   approval_behavior = "ANY"
+  min_approvals     = 2
+}
+
+# Conditions nested deeper than 3 levels are set through condition_json, which
+# takes the same shape as condition.
+resource "crosswire_policy" "break_glass" {
+  owner = {
+    email_address = "user@crosswire.io"
+  }
+  name = "break glass"
+  entitlements = [
+    {
+      provider = "AWS"
+      subject  = "ROLE"
+      object   = "admin"
+    }
+  ]
+  condition_json = jsonencode({
+    quantifier = "ALL"
+    subconditions = [
+      {
+        quantifier = "ANY"
+        subconditions = [
+          {
+            quantifier = "ALL"
+            subconditions = [
+              {
+                # Any 2 of the 3 on-call rotations
+                quantifier = "AT_LEAST"
+                threshold  = 2
+                entitlements = [
+                  {
+                    provider = "OKTA"
+                    subject  = "GROUP"
+                    object   = "sre-oncall"
+                  },
+                  {
+                    provider = "OKTA"
+                    subject  = "GROUP"
+                    object   = "dba-oncall"
+                  },
+                  {
+                    provider = "OKTA"
+                    subject  = "GROUP"
+                    object   = "incident-commanders"
+                  }
+                ]
+              }
+            ]
+          }
+        ]
+      },
+      {
+        # Exclude contractors
+        quantifier = "NONE"
+        entitlements = [
+          {
+            provider = "OKTA"
+            subject  = "GROUP"
+            object   = "contractors"
+          }
+        ]
+      }
+    ]
+  })
+  special_approver = "MANAGER"
+}
+
+# Requests are approved by the requester's manager first, then by any 2 of the
+# security on-call engineers.
+resource "crosswire_policy" "staged" {
+  owner = {
+    email_address = "user@crosswire.io"
+  }
+  name = "production database"
+  entitlements = [
+    {
+      provider = "AWS"
+      subject  = "ROLE"
+      object   = "db-admin"
+    }
+  ]
+  condition = {
+    quantifier = "ANY"
+    entitlements = [
+      {
+        provider = "OKTA"
+        subject  = "GROUP"
+        object   = "engineering"
+      }
+    ]
+  }
+  approval_stages = [
+    {
+      special_approver = "MANAGER"
+    },
+    {
+      user_approvers = [
+        {
+          email_address = "oncall1@crosswire.io"
+        },
+        {
+          email_address = "oncall2@crosswire.io"
+        },
+        {
+          email_address = "oncall3@crosswire.io"
+        }
+      ]
+      min_approvals = 2
+    }
+  ]
+
+  # Every request needs a business reason and a change ticket.
+  require_justification    = true
+  justification_min_length = 20
+  ticket_system            = "SERVICENOW"
+  ticket_pattern           = "^CHG[0-9]{7}$"
+
+  # Access lasts 4 hours unless the request asks for longer, up to a day, and
+  # can be extended twice.
+  default_ttl     = "4h"
+  max_ttl         = "1d"
+  allow_extension = true
+  max_extensions  = 2
+
+  # Access can only be used during London business hours.
+  access_windows = [
+    {
+      days       = ["MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"]
+      start_time = "09:00"
+      end_time   = "17:30"
+      time_zone  = "Europe/London"
+    }
+  ]
 }
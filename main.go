@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/crosswire/terraform-provider-crosswire/crosswire"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -29,11 +31,21 @@ var (
 )
 
 func main() {
-	var debug bool
+	var debug, export bool
+	var exportDir string
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&export, "export", false, "write every Crosswire policy as crosswire_policy resources and import blocks instead of serving the provider")
+	flag.StringVar(&exportDir, "export-dir", ".", "directory the -export files are written to")
 	flag.Parse()
 
+	if export {
+		if err := runExport(context.Background(), exportDir); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		// TODO: Update this string with the published name of your provider.
 		Address: "registry.terraform.io/crosswire-security/crosswire",
@@ -46,3 +58,28 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// runExport reads the same CROSSWIRE_API_HOST and CROSSWIRE_API_TOKEN
+// environment variables as the provider.
+func runExport(ctx context.Context, dir string) error {
+	host := os.Getenv("CROSSWIRE_API_HOST")
+	if host == "" {
+		host = crosswire.HostURL
+	}
+	token := os.Getenv("CROSSWIRE_API_TOKEN")
+	if token == "" {
+		return fmt.Errorf("CROSSWIRE_API_TOKEN must be set to export policies")
+	}
+
+	client, err := crosswire.NewClient(ctx, &host, &token)
+	if err != nil {
+		return err
+	}
+
+	if err := crosswire.ExportPolicies(ctx, client, dir); err != nil {
+		return err
+	}
+
+	log.Printf("wrote %s and %s to %s", crosswire.ExportPoliciesFile, crosswire.ExportImportsFile, dir)
+	return nil
+}